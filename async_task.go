@@ -0,0 +1,106 @@
+package behaviortree
+
+import (
+	"context"
+	"fmt"
+)
+
+// AsyncTask represents a leaf node that runs its work in a goroutine, so that slow operations do not
+// block the tick. The first run launches the work and reports Running; later runs report Running until
+// the work returns, then report Success, or Fail if it returned an error. Halting or finishing the node
+// while the work is in flight cancels its context and discards its result.
+//
+// Halting does not wait for the work to return: the canceled work may still be running, and still be
+// using the object, when the next run launches the work again. RunFunc should return promptly once its
+// context is done and should not modify the object after that.
+type AsyncTask[T any] struct {
+	BaseNode[T] // Inherits functionality from BaseNode for tree-related operations.
+
+	// RunFunc defines the work launched in a goroutine when the task is run. The context is canceled
+	// when the task is halted.
+	RunFunc func(ctx context.Context, object T) error
+
+	// Context is the parent context of the work. If nil, context.Background is used.
+	Context context.Context
+
+	// Err holds the error returned by the last completed run of the work, or nil if it succeeded.
+	Err error
+
+	cancel context.CancelFunc // Cancels the work in flight.
+	done   chan error         // Receives the result of the work in flight. Every launch has its own channel.
+}
+
+// NewAsyncTask creates a new AsyncTask running the given function in a goroutine.
+func NewAsyncTask[T any](runFunc func(ctx context.Context, object T) error) *AsyncTask[T] {
+	return &AsyncTask[T]{
+		RunFunc: runFunc,
+	}
+}
+
+// Run launches the work if it is not in flight and reports its progress to the control node.
+func (t *AsyncTask[T]) Run(object T) {
+	if t.done == nil {
+		t.launch(object)
+		t.Running()
+		return
+	}
+
+	select {
+	case err := <-t.done:
+		t.cancel()
+		t.cancel, t.done = nil, nil
+		t.Err = err
+		if err != nil {
+			t.Fail()
+		} else {
+			t.Success()
+		}
+	default:
+		t.Running()
+	}
+}
+
+// launch starts the work in a goroutine. A panic in the work is reported as an error.
+func (t *AsyncTask[T]) launch(object T) {
+	parent := t.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	done := make(chan error, 1)
+	t.cancel, t.done = cancel, done
+
+	run := t.RunFunc
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("behaviortree: async task panicked: %v", r)
+			}
+		}()
+		done <- run(ctx, object)
+	}()
+}
+
+// InFlight reports whether the work has been launched and has not been collected yet.
+func (t *AsyncTask[T]) InFlight() bool {
+	return t.done != nil
+}
+
+// Halt cancels the work in flight, if any, without waiting for it to return. The channel of the canceled
+// work is dropped, so its result is discarded even if it arrives after the next run launched the work again.
+func (t *AsyncTask[T]) Halt(object T) {
+	if t.done != nil {
+		t.cancel()
+		t.cancel, t.done = nil, nil
+	}
+}
+
+// Finish cancels the work in flight, if any. It has no effect once the work has completed.
+func (t *AsyncTask[T]) Finish(object T) {
+	t.Halt(object)
+}
+
+// Kind reports that the asynchronous task is an action node.
+func (t *AsyncTask[T]) Kind() Kind {
+	return KindAction
+}
@@ -0,0 +1,188 @@
+package behaviortree
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitFor ticks the node until it reports an outcome other than Running.
+func waitFor(t *testing.T, node Node[int]) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if status := TickNode(node, 0); status != Running {
+			return status
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Timed out waiting for the node to complete")
+	return Invalid
+}
+
+func TestAsyncTask_Success(t *testing.T) {
+	release := make(chan struct{})
+	var received int
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		<-release
+		received = obj
+		return nil
+	})
+
+	if status := TickNode[int](task, 7); status != Running {
+		t.Fatalf("Expected Running after launch, but got %v", status)
+	}
+	if status := TickNode[int](task, 8); status != Running || !task.InFlight() {
+		t.Fatalf("Expected Running while the work is in flight, but got %v", status)
+	}
+
+	close(release)
+	if status := waitFor(t, task); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if received != 7 || task.Err != nil || task.InFlight() {
+		t.Errorf("Expected the work to receive 7 and succeed, but got %d (%v)", received, task.Err)
+	}
+}
+
+func TestAsyncTask_Error(t *testing.T) {
+	failure := errors.New("timeout")
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		return failure
+	})
+
+	TickNode[int](task, 0)
+	if status := waitFor(t, task); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+	if !errors.Is(task.Err, failure) {
+		t.Errorf("Expected the error to be kept, but got %v", task.Err)
+	}
+}
+
+func TestAsyncTask_Panic(t *testing.T) {
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		panic("boom")
+	})
+
+	TickNode[int](task, 0)
+	if status := waitFor(t, task); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+	if task.Err == nil || task.Err.Error() != "behaviortree: async task panicked: boom" {
+		t.Errorf("Unexpected error: %v", task.Err)
+	}
+}
+
+func TestAsyncTask_Halt(t *testing.T) {
+	canceled := make(chan error, 1)
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		<-ctx.Done()
+		canceled <- ctx.Err()
+		return ctx.Err()
+	})
+
+	TickNode[int](task, 0)
+	task.Halt(0)
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the context to be canceled, but got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the work to be canceled")
+	}
+	if task.InFlight() {
+		t.Error("Expected no work in flight after halting")
+	}
+
+	task.Halt(0)
+	if status := TickNode[int](task, 0); status != Running || !task.InFlight() {
+		t.Errorf("Expected the work to be launched again, but got %v", status)
+	}
+	task.Finish(0)
+	<-canceled
+}
+
+func TestAsyncTask_HaltDiscardsStaleResult(t *testing.T) {
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		if obj == 1 {
+			// The halted work ignores its context and fails late.
+			<-release
+			defer close(returned)
+			return errors.New("stale")
+		}
+		<-release
+		return nil
+	})
+
+	TickNode[int](task, 1)
+	task.Halt(1)
+	TickNode[int](task, 2)
+	close(release)
+	<-returned
+
+	if status := waitFor(t, task); status != Success || task.Err != nil {
+		t.Errorf("Expected the result of the halted work to be discarded, but got %v (%v)", status, task.Err)
+	}
+}
+
+func TestAsyncTask_Context(t *testing.T) {
+	type key struct{}
+	values := make(chan any, 1)
+	task := NewAsyncTask(func(ctx context.Context, obj int) error {
+		values <- ctx.Value(key{})
+		return nil
+	})
+	task.Context = context.WithValue(context.Background(), key{}, "parent")
+
+	TickNode[int](task, 0)
+	if value := <-values; value != "parent" {
+		t.Errorf("Expected the work to run in a child of the context, but got %v", value)
+	}
+	waitFor(t, task)
+}
+
+func TestAsyncTask_InParallel(t *testing.T) {
+	canceled := make(chan struct{})
+	slow := NewAsyncTask(func(ctx context.Context, obj int) error {
+		<-ctx.Done()
+		close(canceled)
+		return nil
+	})
+	parallel := NewParallel([]Node[int]{slow, statusTask(Running, Failure)}, RequireAll, RequireOne)
+
+	if status := TickNode[int](parallel, 0); status != Running {
+		t.Fatalf("Expected Running, but got %v", status)
+	}
+	if status := TickNode[int](parallel, 0); status != Failure {
+		t.Fatalf("Expected Failure, but got %v", status)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the resolved Parallel node to cancel the work")
+	}
+	if KindOf[int](slow) != KindAction {
+		t.Error("Expected AsyncTask to be an action")
+	}
+}
+
+func TestRegistry_RegisterAsyncTask(t *testing.T) {
+	registry := NewRegistry[int]()
+	registry.RegisterAsyncTask("Fetch", func(ctx context.Context, obj int) error { return nil })
+
+	node, err := registry.Build(Definition{Type: "Fetch"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := node.(*AsyncTask[int]); !ok {
+		t.Errorf("Expected an AsyncTask, but got %T", node)
+	}
+	if _, err := registry.Build(Definition{Type: "Fetch", Children: []Definition{{Type: "Fetch"}}}); err == nil {
+		t.Error("Expected an error for an async task with children")
+	}
+}
@@ -1,5 +1,7 @@
 package behaviortree
 
+import "math/rand"
+
 // BehaviorTree represents the root of a behavior tree. It manages the root node and handles
 // execution flow, including starting, running, and finishing the tree.
 type BehaviorTree[T any] struct {
@@ -7,13 +9,32 @@ type BehaviorTree[T any] struct {
 	RootNode    Node[T] // The root node of the behavior tree.
 	Started     bool    // Indicates whether the behavior tree is currently running.
 	Object      T       // The object shared across nodes during execution.
+	LastStatus  Status  // The status reported by the root node during the last run.
+	TickCount   uint64  // The number of times the tree has been run.
+	Identity            // The optional name and the path ID of the tree.
+
+	blackboard *Blackboard // The blackboard shared by the nodes of the tree.
+	tracer     *tracer[T]  // The tracer dispatching node events to listeners, if any.
 }
 
-// NewBehaviorTree creates a new BehaviorTree with the specified root node.
+// NewBehaviorTree creates a new BehaviorTree with the specified root node and assigns path IDs to its nodes.
 func NewBehaviorTree[T any](rootNode Node[T]) *BehaviorTree[T] {
-	return &BehaviorTree[T]{
+	bt := &BehaviorTree[T]{
 		RootNode: rootNode,
 	}
+	bt.AssignIDs()
+	return bt
+}
+
+// AssignIDs assigns path IDs to the nodes of the tree. The root node is assigned "root", or the ID of
+// the tree followed by "/0" if the tree is nested in another tree. Call it again after changing the
+// structure of the tree.
+func (bt *BehaviorTree[T]) AssignIDs() {
+	id := "root"
+	if bt.ID() != "" {
+		id = bt.ID() + "/0"
+	}
+	AssignIDs(bt.RootNode, id)
 }
 
 // SetControl sets the control node for the behavior tree.
@@ -27,6 +48,36 @@ func (bt *BehaviorTree[T]) SetObject(object T) {
 	bt.Object = object
 }
 
+// SetBlackboard assigns the blackboard shared by the nodes of the tree.
+func (bt *BehaviorTree[T]) SetBlackboard(blackboard *Blackboard) {
+	bt.blackboard = blackboard
+}
+
+// SetRand sets the source of the random choices of every node of the tree implementing Randomized,
+// including the nodes of nested trees, so that runs can be replayed from a seed:
+//
+//	tree.SetRand(rand.New(rand.NewSource(42)))
+//
+// A *rand.Rand is not safe for concurrent use, so trees ticked concurrently need their own sources.
+// Call it again after changing the structure of the tree. If random is nil, the nodes use the global
+// source of math/rand.
+func (bt *BehaviorTree[T]) SetRand(random *rand.Rand) {
+	setRand(bt.RootNode, random)
+}
+
+// Blackboard returns the blackboard shared by the nodes of the tree. A tree without its own blackboard
+// uses the one provided by its control node, so nested trees share the blackboard of the outer tree.
+// Otherwise a new blackboard is created on first use.
+func (bt *BehaviorTree[T]) Blackboard() *Blackboard {
+	if bt.blackboard == nil {
+		if blackboard := blackboardOf(bt.ControlNode); blackboard != nil {
+			return blackboard
+		}
+		bt.blackboard = NewBlackboard()
+	}
+	return bt.blackboard
+}
+
 // Start initializes the behavior tree. Currently unused in this implementation.
 func (bt *BehaviorTree[T]) Start(object T) {
 	// not used in this implementation
@@ -37,16 +88,32 @@ func (bt *BehaviorTree[T]) Finish(object T) {
 	// not used in this implementation
 }
 
-// Run executes the root node of the behavior tree with the provided object.
+// Run executes the root node of the behavior tree with the provided object. The root node is started
+// unless it is still running from the previous run.
 func (bt *BehaviorTree[T]) Run(object T) {
 	bt.Object = object
+	bt.TickCount++
+	if bt.tracer != nil && len(bt.tracer.listeners) > 0 {
+		bt.tracer.instrument(bt)
+	}
 	bt.RootNode.SetControl(bt)
-	bt.RootNode.Start(bt.Object)
+	if !bt.Started {
+		bt.RootNode.Start(bt.Object)
+	}
 	bt.RootNode.Run(bt.Object)
 }
 
+// Tick executes the root node of the behavior tree with the provided object and returns the status
+// reported by the root node. Invalid is returned if the root node did not report an outcome.
+func (bt *BehaviorTree[T]) Tick(object T) Status {
+	bt.LastStatus = Invalid
+	bt.Run(object)
+	return bt.LastStatus
+}
+
 // Running signals that the behavior tree is still in progress. It notifies the control node, if present.
 func (bt *BehaviorTree[T]) Running() {
+	bt.LastStatus = Running
 	if bt.ControlNode != nil {
 		bt.ControlNode.Running()
 	}
@@ -55,6 +122,7 @@ func (bt *BehaviorTree[T]) Running() {
 
 // Success is called when the root node succeeds. It signals success to the control node and finalizes the tree.
 func (bt *BehaviorTree[T]) Success() {
+	bt.LastStatus = Success
 	bt.RootNode.Finish(bt.Object)
 	bt.Started = false
 	if bt.ControlNode != nil {
@@ -64,9 +132,44 @@ func (bt *BehaviorTree[T]) Success() {
 
 // Fail is called when the root node fails. It signals failure to the control node and finalizes the tree.
 func (bt *BehaviorTree[T]) Fail() {
+	bt.LastStatus = Failure
 	bt.RootNode.Finish(bt.Object)
 	bt.Started = false
 	if bt.ControlNode != nil {
 		bt.ControlNode.Fail()
 	}
 }
+
+// Children returns the root node of the behavior tree.
+func (bt *BehaviorTree[T]) Children() []Node[T] {
+	return []Node[T]{bt.RootNode}
+}
+
+// Kind reports that the behavior tree is a tree node.
+func (bt *BehaviorTree[T]) Kind() Kind {
+	return KindTree
+}
+
+// replaceChild replaces the root node of the behavior tree.
+func (bt *BehaviorTree[T]) replaceChild(i int, node Node[T]) {
+	bt.RootNode = node
+}
+
+// AddListener registers a listener receiving an event for every lifecycle transition of the nodes of
+// the tree, and returns a function removing it. Tracing wraps the nodes of the tree in transparent
+// proxies on the next run, so the Nodes fields of the composites hold the proxies while the tree is
+// traced. Walk, ChildrenOf and the exporters see the original nodes.
+func (bt *BehaviorTree[T]) AddListener(listener Listener[T]) (remove func()) {
+	if bt.tracer == nil {
+		bt.tracer = &tracer[T]{tree: bt}
+	}
+	return bt.tracer.add(listener)
+}
+
+// Halt stops the behavior tree, interrupting its running nodes so that the next run starts over.
+func (bt *BehaviorTree[T]) Halt(object T) {
+	if bt.Started {
+		Halt(bt.RootNode, object)
+	}
+	bt.Started = false
+}
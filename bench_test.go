@@ -130,8 +130,84 @@ func BenchmarkUntilFailDecorator(b *testing.B) {
 	}
 }
 
+func BenchmarkParallel_Success(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	parallel := NewParallel[int]([]Node[int]{task, task, task}, RequireAll, RequireOne)
+
+	bt := NewBehaviorTree[int](parallel)
+	bt.SetObject(0)
+
+	for i := 0; i < b.N; i++ {
+		bt.Run(0)
+	}
+}
+
+func BenchmarkReactiveSequence_Success(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	sequence := NewReactiveSequence[int]([]Node[int]{task, task, task})
+
+	bt := NewBehaviorTree[int](sequence)
+	bt.SetObject(0)
+
+	for i := 0; i < b.N; i++ {
+		bt.Run(0)
+	}
+}
+
 var sink interface{} // Global variable to prevent compiler optimizations
 
+func BenchmarkWeightedRandom(b *testing.B) {
+	taskFail := NewTask[int](func(task *Task[int], obj int) {
+		task.Fail()
+	})
+	taskSuccess := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	random := NewWeightedRandom[int]([]Node[int]{taskFail, taskSuccess, taskFail}, []float64{70, 25, 5})
+
+	bt := NewBehaviorTree[int](random)
+	bt.SetObject(0)
+
+	for i := 0; i < b.N; i++ {
+		bt.Run(0)
+	}
+}
+
+func BenchmarkShuffleSelector_Success(b *testing.B) {
+	taskFail := NewTask[int](func(task *Task[int], obj int) {
+		task.Fail()
+	})
+	taskSuccess := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	selector := NewShuffleSelector[int]([]Node[int]{taskFail, taskSuccess, taskFail})
+
+	bt := NewBehaviorTree[int](selector)
+	bt.SetObject(0)
+
+	for i := 0; i < b.N; i++ {
+		bt.Run(0)
+	}
+}
+
+func BenchmarkSubTree(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	library := NewLibrary[int]()
+	library.Add("Recharge", NewSequence[int]([]Node[int]{task, task}))
+	subTree := NewSubTree[int](library, "Recharge", nil)
+	tree := NewBehaviorTree[int](subTree)
+
+	for i := 0; i < b.N; i++ {
+		tree.Run(0)
+	}
+}
+
 func BenchmarkCreateTask(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		sink = NewTask(func(task *Task[int], obj int) {
@@ -158,6 +234,24 @@ func BenchmarkCreatePriority(b *testing.B) {
 	}
 }
 
+func BenchmarkCreateParallel(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	for i := 0; i < b.N; i++ {
+		sink = NewParallel[int]([]Node[int]{task, task, task}, RequireAll, RequireOne)
+	}
+}
+
+func BenchmarkCreateReactiveSequence(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	for i := 0; i < b.N; i++ {
+		sink = NewReactiveSequence[int]([]Node[int]{task, task, task})
+	}
+}
+
 func BenchmarkCreateAlwaysSucceedDecorator(b *testing.B) {
 	task := NewTask[int](func(task *Task[int], obj int) {
 		task.Fail()
@@ -202,3 +296,52 @@ func BenchmarkCreateBehaviorTree(b *testing.B) {
 		sink = NewBehaviorTree[int](task)
 	}
 }
+
+func BenchmarkNewInstance(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	template := NewBehaviorTree[int](NewPriority[int]([]Node[int]{
+		NewSequence[int]([]Node[int]{task, NewInvertDecorator[int](task)}),
+		NewRepeatDecorator[int](task, 3, false),
+	}))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sink, _ = template.NewInstance()
+	}
+}
+
+func BenchmarkScheduler_Tick(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	template := NewBehaviorTree[int](NewSequence[int]([]Node[int]{task, task}))
+	scheduler := NewScheduler[int](0)
+	for i := 0; i < 1000; i++ {
+		instance, _ := template.NewInstance()
+		scheduler.Add(instance, i, 0)
+	}
+
+	for i := 0; i < b.N; i++ {
+		scheduler.Tick()
+	}
+}
+
+func BenchmarkPool_Tick(b *testing.B) {
+	task := NewTask[int](func(task *Task[int], obj int) {
+		task.Success()
+	})
+	template := NewBehaviorTree[int](NewSequence[int]([]Node[int]{task, task}))
+	trees := make([]*BehaviorTree[int], 1000)
+	objects := make([]int, len(trees))
+	for i := range trees {
+		trees[i], _ = template.NewInstance()
+		objects[i] = i
+	}
+	pool := NewPool[int](0)
+
+	for i := 0; i < b.N; i++ {
+		pool.Tick(trees, objects)
+	}
+}
@@ -0,0 +1,183 @@
+package behaviortree
+
+import "sync"
+
+// Blackboard is a key-value store shared by the nodes of a behavior tree. It lets reusable nodes exchange
+// data without knowing the concrete object type T. A blackboard can have child scopes: a scope reads through
+// to its parent but keeps its own writes and deletions local. Blackboard is safe for concurrent use.
+type Blackboard struct {
+	mu        sync.RWMutex
+	parent    *Blackboard       // The enclosing scope, or nil for a root blackboard.
+	values    map[string]any    // The entries stored in this scope.
+	observers []blackboardWatch // The functions notified of changes made to this scope.
+	nextID    int               // The identifier assigned to the next observer.
+}
+
+// Change describes a modification of a blackboard entry.
+type Change struct {
+	Key     string // The name of the modified entry.
+	Old     any    // The previous value stored in the scope, or nil if there was none.
+	New     any    // The new value, or nil if the entry was deleted.
+	Deleted bool   // Indicates whether the entry was deleted.
+}
+
+// blackboardWatch is a registered change observer.
+type blackboardWatch struct {
+	id int
+	fn func(Change)
+}
+
+// BlackboardProvider is implemented by nodes that give their descendants access to a blackboard.
+type BlackboardProvider interface {
+	// Blackboard returns the blackboard visible to the node, or nil if there is none.
+	Blackboard() *Blackboard
+}
+
+// NewBlackboard creates a new, empty root blackboard.
+func NewBlackboard() *Blackboard {
+	return &Blackboard{
+		values: make(map[string]any),
+	}
+}
+
+// NewScope creates a child scope of the blackboard. Lookups fall back to the parent, while writes
+// and deletions only affect the child scope.
+func (b *Blackboard) NewScope() *Blackboard {
+	scope := NewBlackboard()
+	scope.parent = b
+	return scope
+}
+
+// Parent returns the enclosing scope, or nil for a root blackboard.
+func (b *Blackboard) Parent() *Blackboard {
+	return b.parent
+}
+
+// Get returns the value stored under key in this scope or the closest enclosing scope.
+func (b *Blackboard) Get(key string) (any, bool) {
+	for scope := b; scope != nil; scope = scope.parent {
+		scope.mu.RLock()
+		value, ok := scope.values[key]
+		scope.mu.RUnlock()
+		if ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Set stores value under key in this scope and notifies the observers of the scope.
+func (b *Blackboard) Set(key string, value any) {
+	b.mu.Lock()
+	old := b.values[key]
+	b.values[key] = value
+	observers := b.observers
+	b.mu.Unlock()
+
+	notify(observers, Change{Key: key, Old: old, New: value})
+}
+
+// Delete removes key from this scope and notifies the observers of the scope. Entries of enclosing
+// scopes are not affected. It reports whether the key was present in this scope.
+func (b *Blackboard) Delete(key string) bool {
+	b.mu.Lock()
+	old, ok := b.values[key]
+	delete(b.values, key)
+	observers := b.observers
+	b.mu.Unlock()
+
+	if ok {
+		notify(observers, Change{Key: key, Old: old, Deleted: true})
+	}
+	return ok
+}
+
+// Subscribe registers fn to be called after every change made to this scope. Changes made to
+// enclosing or child scopes are not reported. The returned function removes the observer.
+func (b *Blackboard) Subscribe(fn func(Change)) (unsubscribe func()) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	// Copy on write so that notifications in flight keep their snapshot.
+	b.observers = append(b.observers[:len(b.observers):len(b.observers)], blackboardWatch{id: id, fn: fn})
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, watch := range b.observers {
+			if watch.id == id {
+				observers := make([]blackboardWatch, 0, len(b.observers)-1)
+				observers = append(observers, b.observers[:i]...)
+				b.observers = append(observers, b.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls every observer with the change.
+func notify(observers []blackboardWatch, change Change) {
+	for _, watch := range observers {
+		watch.fn(change)
+	}
+}
+
+// Key is a typed name for a blackboard entry. Using keys instead of plain strings keeps the type of an
+// entry consistent between the nodes that read and write it.
+type Key[V any] struct {
+	name string
+}
+
+// NewKey creates a new key for entries of type V stored under the given name.
+func NewKey[V any](name string) Key[V] {
+	return Key[V]{name: name}
+}
+
+// Name returns the name of the entry.
+func (k Key[V]) Name() string {
+	return k.name
+}
+
+// Get returns the value of the entry. The result is false if the entry is missing or holds a value
+// of a different type.
+func (k Key[V]) Get(b *Blackboard) (V, bool) {
+	value, ok := b.Get(k.name)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	typed, ok := value.(V)
+	return typed, ok
+}
+
+// Set stores value in the entry.
+func (k Key[V]) Set(b *Blackboard, value V) {
+	b.Set(k.name, value)
+}
+
+// Delete removes the entry from the blackboard scope. It reports whether the entry was present.
+func (k Key[V]) Delete(b *Blackboard) bool {
+	return b.Delete(k.name)
+}
+
+// Subscribe registers fn to be called whenever the entry is set or deleted in the blackboard scope.
+// The callback receives the new value and true, or the zero value and false after a deletion or when
+// a value of a different type is stored. The returned function removes the observer.
+func (k Key[V]) Subscribe(b *Blackboard, fn func(value V, ok bool)) (unsubscribe func()) {
+	return b.Subscribe(func(change Change) {
+		if change.Key != k.name {
+			return
+		}
+		value, ok := change.New.(V)
+		fn(value, ok && !change.Deleted)
+	})
+}
+
+// blackboardOf returns the blackboard provided by node, or nil if it does not provide one.
+func blackboardOf[T any](node Node[T]) *Blackboard {
+	if provider, ok := node.(BlackboardProvider); ok {
+		return provider.Blackboard()
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package behaviortree
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestBlackboard_SetGetDelete(t *testing.T) {
+	bb := NewBlackboard()
+
+	if _, ok := bb.Get("missing"); ok {
+		t.Error("Expected missing key to not be found")
+	}
+
+	bb.Set("answer", 42)
+	if value, ok := bb.Get("answer"); !ok || value != 42 {
+		t.Errorf("Expected 42, but got %v (found: %v)", value, ok)
+	}
+
+	if !bb.Delete("answer") {
+		t.Error("Expected Delete to report the key as present")
+	}
+	if bb.Delete("answer") {
+		t.Error("Expected second Delete to report the key as absent")
+	}
+	if _, ok := bb.Get("answer"); ok {
+		t.Error("Expected deleted key to not be found")
+	}
+}
+
+func TestBlackboard_Scopes(t *testing.T) {
+	parent := NewBlackboard()
+	scope := parent.NewScope()
+
+	if scope.Parent() != parent {
+		t.Error("Expected scope to reference its parent")
+	}
+	if parent.Parent() != nil {
+		t.Error("Expected root blackboard to have no parent")
+	}
+
+	parent.Set("target", "north")
+	if value, _ := scope.Get("target"); value != "north" {
+		t.Errorf("Expected scope to read through to parent, but got %v", value)
+	}
+
+	scope.Set("target", "south")
+	if value, _ := scope.Get("target"); value != "south" {
+		t.Errorf("Expected scope to shadow parent, but got %v", value)
+	}
+	if value, _ := parent.Get("target"); value != "north" {
+		t.Errorf("Expected parent to be unaffected by scope writes, but got %v", value)
+	}
+
+	scope.Delete("target")
+	if value, _ := scope.Get("target"); value != "north" {
+		t.Errorf("Expected parent value after deleting shadowing entry, but got %v", value)
+	}
+	if scope.Delete("target") {
+		t.Error("Expected Delete to not remove entries of the parent scope")
+	}
+}
+
+func TestBlackboard_Subscribe(t *testing.T) {
+	bb := NewBlackboard()
+	var changes []Change
+	unsubscribe := bb.Subscribe(func(change Change) {
+		changes = append(changes, change)
+	})
+	other := bb.Subscribe(func(change Change) {})
+
+	bb.Set("hp", 10)
+	bb.Set("hp", 5)
+	bb.Delete("hp")
+	bb.Delete("hp")
+
+	expected := []Change{
+		{Key: "hp", New: 10},
+		{Key: "hp", Old: 10, New: 5},
+		{Key: "hp", Old: 5, Deleted: true},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, but got %d", len(expected), len(changes))
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Expected change %d to be %+v, but got %+v", i, expected[i], changes[i])
+		}
+	}
+
+	unsubscribe()
+	unsubscribe()
+	bb.Set("hp", 1)
+	if len(changes) != len(expected) {
+		t.Error("Expected no notifications after unsubscribing")
+	}
+	other()
+	if len(bb.observers) != 0 {
+		t.Errorf("Expected no observers left, but got %d", len(bb.observers))
+	}
+}
+
+func TestKey_Typed(t *testing.T) {
+	bb := NewBlackboard()
+	health := NewKey[int]("health")
+
+	if health.Name() != "health" {
+		t.Errorf("Expected key name to be health, but got %q", health.Name())
+	}
+	if _, ok := health.Get(bb); ok {
+		t.Error("Expected missing entry to not be found")
+	}
+
+	health.Set(bb, 80)
+	if value, ok := health.Get(bb); !ok || value != 80 {
+		t.Errorf("Expected 80, but got %v (found: %v)", value, ok)
+	}
+
+	bb.Set("health", "full")
+	if _, ok := health.Get(bb); ok {
+		t.Error("Expected entry of a different type to not be returned")
+	}
+
+	if !health.Delete(bb) {
+		t.Error("Expected Delete to report the entry as present")
+	}
+}
+
+func TestKey_Subscribe(t *testing.T) {
+	bb := NewBlackboard()
+	target := NewKey[string]("target")
+
+	type event struct {
+		value string
+		ok    bool
+	}
+	var events []event
+	unsubscribe := target.Subscribe(bb, func(value string, ok bool) {
+		events = append(events, event{value, ok})
+	})
+
+	target.Set(bb, "intruder")
+	bb.Set("other", 1)
+	bb.Set("target", 7)
+	target.Delete(bb)
+	unsubscribe()
+	target.Set(bb, "ignored")
+
+	expected := []event{{"intruder", true}, {"", false}, {"", false}}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, but got %d", len(expected), len(events))
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("Expected event %d to be %+v, but got %+v", i, expected[i], events[i])
+		}
+	}
+}
+
+func TestBlackboard_ConcurrentAccess(t *testing.T) {
+	bb := NewBlackboard()
+	scope := bb.NewScope()
+	counter := NewKey[int]("counter")
+	bb.Subscribe(func(Change) {})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				counter.Set(bb, i)
+				counter.Get(scope)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if _, ok := counter.Get(scope); !ok {
+		t.Error("Expected counter to be set")
+	}
+}
+
+func TestTask_Blackboard(t *testing.T) {
+	target := NewKey[string]("target")
+	var seen string
+
+	write := NewTask(func(task *Task[int], obj int) {
+		target.Set(task.Blackboard(), "intruder")
+		task.Success()
+	})
+	read := NewTask(func(task *Task[int], obj int) {
+		seen, _ = target.Get(task.Blackboard())
+		task.Success()
+	})
+
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{
+		write,
+		NewPriority([]Node[int]{NewAlwaysFailDecorator[int](read)}),
+	}))
+
+	if status := tree.Tick(0); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+	if seen != "intruder" {
+		t.Errorf("Expected task to read the value written by its sibling, but got %q", seen)
+	}
+	if value, _ := target.Get(tree.Blackboard()); value != "intruder" {
+		t.Errorf("Expected tree blackboard to hold the value, but got %q", value)
+	}
+}
+
+func TestBehaviorTree_SetBlackboard(t *testing.T) {
+	bb := NewBlackboard()
+	var seen *Blackboard
+	task := NewTask(func(task *Task[int], obj int) {
+		seen = task.Blackboard()
+		task.Success()
+	})
+
+	tree := NewBehaviorTree[int](NewParallel([]Node[int]{task}, RequireAll, RequireAll))
+	tree.SetBlackboard(bb)
+	tree.Tick(0)
+
+	if seen != bb {
+		t.Error("Expected task in a Parallel node to reach the tree blackboard")
+	}
+}
+
+func TestBehaviorTree_NestedTreeSharesBlackboard(t *testing.T) {
+	var seen *Blackboard
+	inner := NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) {
+		seen = task.Blackboard()
+		task.Success()
+	}))
+	outer := NewBehaviorTree[int](NewSequence([]Node[int]{inner}))
+
+	outer.Tick(0)
+
+	if seen == nil || seen != outer.Blackboard() {
+		t.Error("Expected nested tree to use the blackboard of the outer tree")
+	}
+}
+
+func TestBlackboard_WithoutProvider(t *testing.T) {
+	task := NewTask(func(task *Task[int], obj int) {})
+	if task.Blackboard() != nil {
+		t.Error("Expected task without control node to have no blackboard")
+	}
+
+	task.SetControl(&MockNode[int]{})
+	if task.Blackboard() != nil {
+		t.Error("Expected task under a node without blackboard to have no blackboard")
+	}
+
+	if TickNode[int](NewParallel([]Node[int]{task}, RequireAll, RequireAll), 0); task.Blackboard() != nil {
+		t.Error("Expected task ticked without a tree to have no blackboard")
+	}
+}
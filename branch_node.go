@@ -67,4 +67,32 @@ func (b *BranchNode[T]) Fail() {
 		b.Node.Finish(b.Object)
 	}
 	b.Node = nil
-}
\ No newline at end of file
+}
+
+// Children returns the child nodes of the branch node.
+func (b *BranchNode[T]) Children() []Node[T] {
+	return b.Nodes
+}
+
+// Kind reports that the branch node is a composite node.
+func (b *BranchNode[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i, including the active child node.
+func (b *BranchNode[T]) replaceChild(i int, node Node[T]) {
+	if b.Node == b.Nodes[i] {
+		b.Node = node
+	}
+	b.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the branch node.
+func (b *BranchNode[T]) Halt(object T) {
+	if b.NodeRunning && b.Node != nil {
+		Halt(b.Node, object)
+	}
+	b.NodeRunning = false
+	b.Node = nil
+	b.ActualTask = 0
+}
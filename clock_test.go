@@ -0,0 +1,34 @@
+package behaviortree
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected %v, but got %v", start, clock.Now())
+	}
+	clock.Advance(time.Minute)
+	if !clock.Now().Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the clock to advance by a minute, but got %v", clock.Now())
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("Expected the clock to be set back, but got %v", clock.Now())
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock{}.Now()
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("Expected the system time, but got %v", now)
+	}
+	if _, ok := clockOrSystem(nil).(SystemClock); !ok {
+		t.Error("Expected the system clock to be used by default")
+	}
+}
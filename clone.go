@@ -0,0 +1,316 @@
+package behaviortree
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Cloner is implemented by nodes that can be copied into a new instance of a tree. All built-in nodes
+// implement it. Custom nodes implement it to take part in Clone, unless they embed BaseNode, BranchNode
+// or Decorator and can be copied field by field.
+type Cloner[T any] interface {
+	// Clone returns a copy of the node with fresh execution state that shares the configuration of the
+	// node, such as its functions, thresholds and name. The copy keeps the original child nodes, which
+	// Clone replaces with their own copies, but it must not share the slice holding them.
+	Clone() Node[T]
+}
+
+// Clone returns a copy of the tree rooted at node with fresh execution state, so that a tree built once
+// can be instantiated for many agents. The copies share the configuration of the original nodes, such as
+// the functions of tasks and conditions, but none of their execution state; tracing listeners and
+// blackboards are not copied. Nodes with children must embed BranchNode or Decorator so that their
+// children can be replaced.
+//
+// Every node must implement Cloner or embed BaseNode, BranchNode or Decorator by value. A custom node
+// that embeds one of them and does not implement Cloner is copied field by field, and the execution
+// state of the embedded node, such as its control node, object and running child, is reset; it must
+// implement Cloner if its own fields hold execution state or values the copies must not share.
+// The original tree is left untouched,
+// except that randomized nodes with a source of their own draw the seed of the source of their copy from
+// it, so copies never share a *rand.Rand; a tree with such sources must not be cloned by several
+// goroutines at once.
+func Clone[T any](node Node[T]) (Node[T], error) {
+	node = unwrap(node)
+	if node == nil {
+		return nil, nil
+	}
+	var clone Node[T]
+	if cloner, ok := node.(Cloner[T]); ok {
+		clone = cloner.Clone()
+	} else if copied, ok := copyNode(node); ok {
+		clone = copied
+	} else {
+		return nil, fmt.Errorf("behaviortree: cannot clone node %s of type %T: it does not implement Cloner", describeNode(node), node)
+	}
+	if reflect.TypeOf(clone) != reflect.TypeOf(node) {
+		return nil, fmt.Errorf("behaviortree: cannot clone node %s of type %T: Clone returned a %T", describeNode(node), node, clone)
+	}
+
+	children := ChildrenOf(node)
+	if len(children) == 0 {
+		return clone, nil
+	}
+	replacer, ok := clone.(childReplacer[T])
+	if !ok {
+		return nil, fmt.Errorf("behaviortree: cannot clone node %s of type %T: its children cannot be replaced", describeNode(node), node)
+	}
+	for i, child := range children {
+		childClone, err := Clone(child)
+		if err != nil {
+			return nil, err
+		}
+		if childClone == nil {
+			continue
+		}
+		replacer.replaceChild(i, childClone)
+		childClone.SetControl(clone)
+	}
+	return clone, nil
+}
+
+// NewInstance returns a new instance of the tree as described by Clone. The instance has its own
+// execution state and blackboard, so that many agents can run the same tree. An instance is a full copy
+// of the nodes of the tree, not a view of a shared definition, so creating one allocates every node again.
+func (bt *BehaviorTree[T]) NewInstance() (*BehaviorTree[T], error) {
+	instance, err := Clone[T](bt)
+	if err != nil {
+		return nil, err
+	}
+	return instance.(*BehaviorTree[T]), nil
+}
+
+// embeddedBase is implemented by nodes embedding BaseNode, BranchNode or Decorator.
+type embeddedBase[T any] interface {
+	baseNode() *BaseNode[T]
+	resetState()
+}
+
+// copyNode returns a copy of a node embedding BaseNode, BranchNode or Decorator by value, with the
+// execution state of the embedded node reset, or false if the node does not embed one of them by value.
+func copyNode[T any](node Node[T]) (Node[T], bool) {
+	original, ok := node.(embeddedBase[T])
+	if !ok {
+		return nil, false
+	}
+	value := reflect.ValueOf(node).Elem()
+	copied := reflect.New(value.Type())
+	copied.Elem().Set(value)
+	clone := copied.Interface().(embeddedBase[T])
+	if clone.baseNode() == original.baseNode() {
+		// The node embeds a pointer, which the copy would share.
+		return nil, false
+	}
+	clone.resetState()
+	return clone.(Node[T]), true
+}
+
+// baseNode returns the embedded BaseNode.
+func (n *BaseNode[T]) baseNode() *BaseNode[T] {
+	return n
+}
+
+// resetState resets the control node and the object of a copied node.
+func (n *BaseNode[T]) resetState() {
+	var zero T
+	n.ControlNode = nil
+	n.Object = zero
+}
+
+// resetState resets the execution state of a copied branch node and gives it its own slice of children.
+func (b *BranchNode[T]) resetState() {
+	b.BaseNode.resetState()
+	b.Nodes = cloneNodes(b.Nodes)
+	b.Node = nil
+	b.ActualTask = 0
+	b.NodeRunning = false
+}
+
+// cloneNodes returns a copy of the slice of child nodes.
+func cloneNodes[T any](nodes []Node[T]) []Node[T] {
+	return append([]Node[T](nil), nodes...)
+}
+
+// Clone returns a copy of the tree with fresh execution state.
+func (bt *BehaviorTree[T]) Clone() Node[T] {
+	return &BehaviorTree[T]{RootNode: bt.RootNode, Identity: bt.Identity}
+}
+
+// Clone returns a copy of the task with fresh execution state.
+func (t *Task[T]) Clone() Node[T] {
+	clone := NewTask(t.RunFunc)
+	clone.Identity, clone.Origin = t.Identity, t.Origin
+	return clone
+}
+
+// Clone returns a copy of the task with fresh execution state.
+func (t *AsyncTask[T]) Clone() Node[T] {
+	clone := NewAsyncTask(t.RunFunc)
+	clone.Context = t.Context
+	clone.Identity, clone.Origin = t.Identity, t.Origin
+	return clone
+}
+
+// Clone returns a copy of the condition.
+func (c *Condition[T]) Clone() Node[T] {
+	clone := NewCondition(c.CheckFunc)
+	clone.Identity, clone.Origin = c.Identity, c.Origin
+	return clone
+}
+
+// Clone returns a copy of the sequence with fresh execution state.
+func (s *Sequence[T]) Clone() Node[T] {
+	return &Sequence[T]{Nodes: cloneNodes(s.Nodes), Identity: s.Identity, Origin: s.Origin}
+}
+
+// Clone returns a copy of the Priority node with fresh execution state.
+func (p *Priority[T]) Clone() Node[T] {
+	return &Priority[T]{Nodes: cloneNodes(p.Nodes), Identity: p.Identity, Origin: p.Origin}
+}
+
+// Clone returns a copy of the ReactiveSequence node with fresh execution state.
+func (s *ReactiveSequence[T]) Clone() Node[T] {
+	return &ReactiveSequence[T]{Nodes: cloneNodes(s.Nodes), Identity: s.Identity, Origin: s.Origin}
+}
+
+// Clone returns a copy of the ReactiveFallback node with fresh execution state.
+func (f *ReactiveFallback[T]) Clone() Node[T] {
+	return &ReactiveFallback[T]{Nodes: cloneNodes(f.Nodes), Identity: f.Identity, Origin: f.Origin}
+}
+
+// Clone returns a copy of the Parallel node with fresh execution state.
+func (p *Parallel[T]) Clone() Node[T] {
+	clone := NewParallel(cloneNodes(p.Nodes), p.SuccessThreshold, p.FailureThreshold)
+	clone.Identity, clone.Origin = p.Identity, p.Origin
+	return clone
+}
+
+// Clone returns a copy of the Random node with fresh execution state and a random source derived from
+// its own.
+func (r *Random[T]) Clone() Node[T] {
+	clone := NewRandom(cloneNodes(r.Nodes))
+	clone.Rand = deriveRand(r.Rand)
+	clone.Identity, clone.Origin = r.Identity, r.Origin
+	return clone
+}
+
+// Clone returns a copy of the WeightedRandom node with fresh execution state that shares its weights
+// and has a random source derived from its own.
+func (w *WeightedRandom[T]) Clone() Node[T] {
+	return &WeightedRandom[T]{
+		Nodes:       cloneNodes(w.Nodes),
+		Weights:     w.Weights,
+		WeightFuncs: w.WeightFuncs,
+		Rand:        deriveRand(w.Rand),
+		Identity:    w.Identity,
+		Origin:      w.Origin,
+	}
+}
+
+// Clone returns a copy of the ShuffleSelector node with fresh execution state and a random source derived
+// from its own.
+func (s *ShuffleSelector[T]) Clone() Node[T] {
+	return &ShuffleSelector[T]{Nodes: cloneNodes(s.Nodes), Rand: deriveRand(s.Rand), Identity: s.Identity, Origin: s.Origin}
+}
+
+// Clone returns a copy of the ShuffleSequence node with fresh execution state and a random source derived
+// from its own.
+func (s *ShuffleSequence[T]) Clone() Node[T] {
+	return &ShuffleSequence[T]{Nodes: cloneNodes(s.Nodes), Rand: deriveRand(s.Rand), Identity: s.Identity, Origin: s.Origin}
+}
+
+// The decorators are copied without their constructors, which would make the child node of the
+// original decorator report to the copy. Clone wires the copies of the child nodes instead.
+
+// Clone returns a copy of the decorator.
+func (d *InvertDecorator[T]) Clone() Node[T] {
+	clone := &InvertDecorator[T]{}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator.
+func (d *AlwaysSucceedDecorator[T]) Clone() Node[T] {
+	clone := &AlwaysSucceedDecorator[T]{}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator.
+func (d *AlwaysFailDecorator[T]) Clone() Node[T] {
+	clone := &AlwaysFailDecorator[T]{}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state.
+func (d *UntilFailDecorator[T]) Clone() Node[T] {
+	clone := &UntilFailDecorator[T]{}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator. The copy creates its own scope of the blackboard.
+func (d *ScopeDecorator[T]) Clone() Node[T] {
+	clone := &ScopeDecorator[T]{}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state.
+func (d *ConditionalDecorator[T]) Clone() Node[T] {
+	clone := &ConditionalDecorator[T]{Condition: d.Condition, Abort: d.Abort}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state.
+func (d *GuardDecorator[T]) Clone() Node[T] {
+	clone := &GuardDecorator[T]{Guard: d.Guard, Recheck: d.Recheck}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state.
+func (d *RepeatDecorator[T]) Clone() Node[T] {
+	clone := &RepeatDecorator[T]{Count: d.Count, IgnoreFailure: d.IgnoreFailure}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state that shares its Backoff. The backoffs
+// of this package are safe to share; a custom Backoff drawing from a *rand.Rand must lock it.
+func (d *RetryDecorator[T]) Clone() Node[T] {
+	clone := &RetryDecorator[T]{MaxAttempts: d.MaxAttempts, Backoff: d.Backoff, Clock: d.Clock, TickDuration: d.TickDuration}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state.
+func (d *TimeoutDecorator[T]) Clone() Node[T] {
+	clone := &TimeoutDecorator[T]{Timeout: d.Timeout, Clock: d.Clock}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state, so that the copy has its own cooldown.
+func (d *CooldownDecorator[T]) Clone() Node[T] {
+	clone := &CooldownDecorator[T]{Cooldown: d.Cooldown, Clock: d.Clock}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the decorator with fresh execution state, so that the copy has its own limit.
+func (d *RateLimitDecorator[T]) Clone() Node[T] {
+	clone := &RateLimitDecorator[T]{Limit: d.Limit, Window: d.Window, Clock: d.Clock}
+	clone.Node, clone.Identity, clone.Origin = d.Node, d.Identity, d.Origin
+	return clone
+}
+
+// Clone returns a copy of the SubTree node with fresh execution state that references the same tree.
+// The copy gets its own blackboard, and its own instance of the tree once the instance has been created.
+func (s *SubTree[T]) Clone() Node[T] {
+	clone := NewSubTree(s.Library, s.Tree, s.Remap)
+	clone.Node, clone.Identity, clone.Origin = s.Node, s.Identity, s.Origin
+	clone.Rand = deriveRand(s.Rand)
+	return clone
+}
@@ -0,0 +1,302 @@
+package behaviortree
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// cloneableParent is a custom node with children that can be cloned but whose children cannot be replaced.
+type cloneableParent struct {
+	parentNode
+}
+
+func (p *cloneableParent) Clone() Node[int] {
+	return &cloneableParent{}
+}
+
+// countingDecorator is a custom decorator without a Clone method, counting the successes of its child.
+type countingDecorator struct {
+	Decorator[int]
+	label     string
+	successes int
+}
+
+func (d *countingDecorator) Success() {
+	d.successes++
+	d.ControlNode.Success()
+}
+
+// firstChild is a custom composite without a Clone method, running its first child.
+type firstChild struct {
+	BranchNode[int]
+}
+
+func (f *firstChild) Run(obj int) {
+	f.Nodes[0].Run(obj)
+}
+
+func (f *firstChild) Success() {
+	f.ControlNode.Success()
+}
+
+// sharedDecorator is a custom decorator embedding a pointer, which copies would share.
+type sharedDecorator struct {
+	*Decorator[int]
+}
+
+// runningTask returns a task that reports Running for positive objects and succeeds otherwise.
+func runningTask() *Task[int] {
+	return NewTask(func(task *Task[int], obj int) {
+		if obj > 0 {
+			task.Running()
+		} else {
+			task.Success()
+		}
+	})
+}
+
+// everyNodeTree returns a tree using every built-in node type.
+func everyNodeTree() *BehaviorTree[int] {
+	leaf := func() Node[int] { return runningTask() }
+	return NewBehaviorTree[int](Named(NewSequence([]Node[int]{
+		NewPriority([]Node[int]{NewRandom([]Node[int]{leaf()}), NewWeightedRandom([]Node[int]{leaf()}, []float64{2})}),
+		NewShuffleSelector([]Node[int]{NewShuffleSequence([]Node[int]{NewCondition(func(obj int) bool { return true })})}),
+		NewReactiveSequence([]Node[int]{NewReactiveFallback([]Node[int]{NewAsyncTask(func(ctx context.Context, obj int) error { return nil })})}),
+		NewParallel([]Node[int]{NewInvertDecorator[int](leaf()), NewAlwaysSucceedDecorator[int](leaf())}, RequireOne, RequireAll),
+		NewAlwaysFailDecorator[int](NewUntilFailDecorator[int](NewScopeDecorator[int](leaf()))),
+		NewConditionalDecorator[int](NewGuardDecorator[int](leaf(), CheckerFunc[int](func(obj int) bool { return true }), true), ConditionOf[int](NewCondition(func(obj int) bool { return true })), AbortSelf),
+		NewRepeatDecorator[int](NewRetryDecorator[int](leaf(), 3, FixedBackoff(time.Second)), 2, true),
+		NewTimeoutDecorator[int](NewCooldownDecorator[int](NewRateLimitDecorator[int](leaf(), 5, time.Minute), time.Second), time.Hour),
+		NewBehaviorTree[int](leaf()),
+	}), "root"))
+}
+
+func TestClone_EveryNode(t *testing.T) {
+	template := everyNodeTree()
+	instance, err := template.NewInstance()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var originals, clones []Node[int]
+	Walk[int](template, func(node Node[int], depth int) bool {
+		originals = append(originals, node)
+		return true
+	})
+	Walk[int](instance, func(node Node[int], depth int) bool {
+		clones = append(clones, node)
+		return true
+	})
+	if len(originals) != len(clones) {
+		t.Fatalf("Expected %d nodes, but got %d", len(originals), len(clones))
+	}
+	for i := range originals {
+		if originals[i] == clones[i] {
+			t.Errorf("Expected node %s to be copied", describeNode(originals[i]))
+		}
+		if TypeName(originals[i]) != TypeName(clones[i]) || IDOf(originals[i]) != IDOf(clones[i]) || NameOf(originals[i]) != NameOf(clones[i]) {
+			t.Errorf("Expected %s to keep its type and identity, but got %s", describeNode(originals[i]), describeNode(clones[i]))
+		}
+	}
+}
+
+func TestClone_IndependentInstances(t *testing.T) {
+	template := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewCondition(func(obj int) bool { return obj >= 0 }),
+		NewTimeoutDecorator[int](runningTask(), time.Hour),
+	}))
+	first, _ := template.NewInstance()
+	second, _ := template.NewInstance()
+
+	if status := first.Tick(1); status != Running {
+		t.Errorf("Expected the first instance to be running, but got %v", status)
+	}
+	if status := second.Tick(0); status != Success {
+		t.Errorf("Expected the second instance to succeed, but got %v", status)
+	}
+	if !first.Started || second.Started || template.Started {
+		t.Error("Expected the instances to keep their own execution state")
+	}
+	if first.Blackboard() == second.Blackboard() {
+		t.Error("Expected the instances to have their own blackboards")
+	}
+	if status := template.Tick(0); status != Success {
+		t.Errorf("Expected the template to keep working, but got %v", status)
+	}
+}
+
+// randomizedTree returns a tree of every randomized node type drawing from a source with the given seed.
+func randomizedTree(seed int64) *BehaviorTree[int] {
+	leaves := func() []Node[int] {
+		var nodes []Node[int]
+		for i := 2; i < 6; i++ {
+			i := i
+			nodes = append(nodes, NewCondition(func(obj int) bool { return obj%i == 0 }))
+		}
+		return nodes
+	}
+	random := rand.New(rand.NewSource(seed))
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewRandom(leaves()),
+		NewWeightedRandom(leaves(), []float64{0.1, 0.2, 0.3, 0.4}),
+		NewShuffleSelector(leaves()),
+		NewAlwaysSucceedDecorator[int](NewShuffleSequence(leaves())),
+		NewRetryDecorator[int](NewCondition(func(obj int) bool { return obj%7 == 0 }), 2, JitterBackoff(FixedBackoff(0), 0.5, random)),
+	}))
+	tree.SetRand(random)
+	return tree
+}
+
+// randomSources returns the sources of the randomized nodes of the tree, and the orders they drew.
+func randomSources(tree *BehaviorTree[int]) ([]*rand.Rand, [][]int) {
+	var sources []*rand.Rand
+	var orders [][]int
+	Walk[int](tree, func(node Node[int], depth int) bool {
+		switch n := node.(type) {
+		case *Random[int]:
+			sources = append(sources, n.Rand)
+		case *WeightedRandom[int]:
+			sources, orders = append(sources, n.Rand), append(orders, n.Order)
+		case *ShuffleSelector[int]:
+			sources, orders = append(sources, n.Rand), append(orders, n.Order)
+		case *ShuffleSequence[int]:
+			sources, orders = append(sources, n.Rand), append(orders, n.Order)
+		}
+		return true
+	})
+	return sources, orders
+}
+
+func TestClone_RandomSources(t *testing.T) {
+	template := randomizedTree(42)
+	instances := make([]*BehaviorTree[int], 8)
+	seen := make(map[*rand.Rand]bool)
+	for i := range instances {
+		instances[i], _ = template.NewInstance()
+		sources, _ := randomSources(instances[i])
+		for _, source := range sources {
+			if source == nil || seen[source] || source == template.RootNode.(*Sequence[int]).Nodes[0].(*Random[int]).Rand {
+				t.Fatalf("Expected every randomized node of every instance to have a source of its own")
+			}
+			seen[source] = true
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, instance := range instances {
+		wg.Add(1)
+		go func(instance *BehaviorTree[int]) {
+			defer wg.Done()
+			for tick := 0; tick < 100; tick++ {
+				instance.Tick(tick)
+			}
+		}(instance)
+	}
+	wg.Wait()
+
+	first, _ := randomizedTree(7).NewInstance()
+	second, _ := randomizedTree(7).NewInstance()
+	first.Tick(0)
+	second.Tick(0)
+	_, firstOrders := randomSources(first)
+	_, secondOrders := randomSources(second)
+	if !reflect.DeepEqual(firstOrders, secondOrders) {
+		t.Error("Expected instances of templates with the same seed to draw the same choices")
+	}
+}
+
+func TestClone_LeavesTemplateWired(t *testing.T) {
+	child := runningTask()
+	invert := NewInvertDecorator[int](child)
+	if _, err := Clone[int](invert); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if child.ControlNode != invert {
+		t.Error("Expected the child of the template to keep reporting to the template")
+	}
+}
+
+func TestClone_TracedTree(t *testing.T) {
+	template := NewBehaviorTree[int](NewSequence([]Node[int]{runningTask()}))
+	var events []Event[int]
+	template.AddListener(ListenerFunc[int](func(event Event[int]) { events = append(events, event) }))
+	template.Tick(0)
+
+	count := len(events)
+	instance, err := template.NewInstance()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	instance.Tick(0)
+	if len(events) != count {
+		t.Error("Expected the instance not to be traced")
+	}
+	if _, traced := instance.RootNode.(*Sequence[int]).Nodes[0].(*tracedNode[int]); traced {
+		t.Error("Expected the children of the instance not to be wrapped")
+	}
+
+	clone, err := Clone[int](NewSequence([]Node[int]{nil}))
+	if err != nil || clone.(*Sequence[int]).Nodes[0] != nil {
+		t.Errorf("Expected the nil child to be kept, but got %v (%v)", clone, err)
+	}
+	if clone, err := Clone[int](nil); clone != nil || err != nil {
+		t.Errorf("Expected a nil node to clone to nil, but got %v (%v)", clone, err)
+	}
+}
+
+func TestClone_EmbeddedBases(t *testing.T) {
+	decorator := &countingDecorator{label: "counted"}
+	decorator.Node = runningTask()
+	decorator.Node.SetControl(decorator)
+	branch := &firstChild{BranchNode: *NewBranchNode([]Node[int]{decorator})}
+	decorator.SetControl(branch)
+	template := NewBehaviorTree[int](branch)
+	if status := template.Tick(0); status != Success {
+		t.Fatalf("Expected the template to succeed, but got %v", status)
+	}
+	branch.Object, branch.ActualTask = 7, 1
+
+	instance, err := template.NewInstance()
+	if err != nil {
+		t.Fatalf("Expected custom nodes embedding the bases to be cloned, but got %v", err)
+	}
+	branchClone := instance.RootNode.(*firstChild)
+	decoratorClone := branchClone.Nodes[0].(*countingDecorator)
+	if decoratorClone == decorator || decoratorClone.Node == decorator.Node || decoratorClone.label != "counted" {
+		t.Error("Expected the custom nodes to be copied with their configuration")
+	}
+	if branchClone.ControlNode != instance || decoratorClone.ControlNode != branchClone || decoratorClone.Node.(*Task[int]).ControlNode != decoratorClone {
+		t.Error("Expected the copies to be wired to each other")
+	}
+	if &branchClone.Nodes[0] == &branch.Nodes[0] || branchClone.Object != 0 || branchClone.ActualTask != 0 {
+		t.Error("Expected the copied branch to get its own children and fresh state")
+	}
+
+	if status := instance.Tick(0); status != Success || decorator.successes != 1 {
+		t.Errorf("Expected the copy to run on its own, but got %v with %d successes of the template", status, decorator.successes)
+	}
+}
+
+func TestClone_Errors(t *testing.T) {
+	cases := []struct {
+		node    Node[int]
+		message string
+	}{
+		{NewSequence([]Node[int]{NewMockNode[int](t)}), "of type *behaviortree.MockNode[int]: it does not implement Cloner"},
+		{NewSequence([]Node[int]{newHaltableTask(Success)}), "Clone returned a *behaviortree.Task[int]"},
+		{NewSequence([]Node[int]{&cloneableParent{parentNode{nodes: []Node[int]{runningTask()}}}}), "its children cannot be replaced"},
+		{NewSequence([]Node[int]{&sharedDecorator{NewDecorator[int](runningTask())}}), "of type *behaviortree.sharedDecorator: it does not implement Cloner"},
+	}
+	for _, c := range cases {
+		tree := NewBehaviorTree[int](c.node)
+		_, err := tree.NewInstance()
+		if err == nil || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("Expected an error containing %q, but got %v", c.message, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package behaviortree
+
+// Checker is implemented by nodes that check a condition without side effects, such as Condition.
+// Decorators accept checkers as guards, so that a condition node can gate another branch.
+type Checker[T any] interface {
+	// Check reports whether the condition holds for the object.
+	Check(object T) bool
+}
+
+// CheckerFunc adapts a function to the Checker interface.
+type CheckerFunc[T any] func(object T) bool
+
+// Check calls the function with the object.
+func (f CheckerFunc[T]) Check(object T) bool {
+	return f(object)
+}
+
+// Condition is a leaf node that checks a condition without side effects. It succeeds if the condition
+// holds and fails otherwise, and never reports Running. Unlike a Task, a Condition is reported as
+// KindCondition, so that visualizers and validators can tell checks from actions.
+type Condition[T any] struct {
+	BaseNode[T] // Inherits functionality from BaseNode for tree-related operations.
+
+	// CheckFunc decides whether the condition holds for the object.
+	CheckFunc func(object T) bool
+}
+
+// NewCondition creates a new Condition checking the specified function.
+func NewCondition[T any](check func(object T) bool) *Condition[T] {
+	return &Condition[T]{
+		CheckFunc: check,
+	}
+}
+
+// Check reports whether the condition holds for the object. A Condition without a CheckFunc never holds.
+func (c *Condition[T]) Check(object T) bool {
+	return c.CheckFunc != nil && c.CheckFunc(object)
+}
+
+// Run checks the condition and signals success if it holds, and failure otherwise.
+func (c *Condition[T]) Run(object T) {
+	if c.Check(object) {
+		c.Success()
+	} else {
+		c.Fail()
+	}
+}
+
+// Kind reports that the node is a condition node.
+func (c *Condition[T]) Kind() Kind {
+	return KindCondition
+}
+
+// ConditionOf adapts a checker, such as a Condition node, into the condition of a ConditionalDecorator.
+func ConditionOf[T any](checker Checker[T]) func(decorator *ConditionalDecorator[T], object T) bool {
+	return func(decorator *ConditionalDecorator[T], object T) bool {
+		return checker.Check(object)
+	}
+}
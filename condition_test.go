@@ -0,0 +1,67 @@
+package behaviortree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCondition_Run(t *testing.T) {
+	condition := NewCondition(func(obj int) bool { return obj > 0 })
+
+	if status := TickNode[int](condition, 1); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if status := TickNode[int](condition, 0); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+	if status := TickNode[int](&Condition[int]{}, 1); status != Failure {
+		t.Errorf("Expected a condition without a check to fail, but got %v", status)
+	}
+}
+
+func TestCondition_Kind(t *testing.T) {
+	condition := NewCondition(func(obj int) bool { return true })
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{condition, statusTask(Success)}))
+
+	if kind := KindOf[int](condition); kind != KindCondition {
+		t.Errorf("Expected KindCondition, but got %v", kind)
+	}
+	var buf bytes.Buffer
+	if err := WriteDOT[int](&buf, tree, RenderOptions[int]{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `label="Condition", shape=ellipse`) {
+		t.Errorf("Expected the condition to be drawn as an ellipse, got:\n%s", buf.String())
+	}
+}
+
+func TestCondition_Guard(t *testing.T) {
+	charged := true
+	battery := NewCondition(func(obj int) bool { return charged })
+	child := newHaltableTask(Running)
+	tree := NewBehaviorTree[int](NewConditionalDecorator[int](child, ConditionOf[int](battery), AbortSelf))
+
+	first := tree.Tick(0)
+	charged = false
+	second := tree.Tick(0)
+	if first != Running || second != Failure || child.halted != 1 {
+		t.Errorf("Expected the condition to guard the child, got %v, %v and %d halts", first, second, child.halted)
+	}
+}
+
+func TestRegistry_RegisterCondition(t *testing.T) {
+	registry := newTestRegistry()
+	registry.RegisterCondition("Positive", func(obj int) bool { return obj > 0 })
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><Condition ID="Positive"/></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(1); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if _, err := registry.Build(Definition{Type: "Positive", Children: []Definition{{Type: "Succeed"}}}); err == nil {
+		t.Error("Expected an error for a condition with children")
+	}
+}
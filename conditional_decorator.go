@@ -0,0 +1,138 @@
+package behaviortree
+
+// AbortMode selects which running branches a ConditionalDecorator interrupts when its condition changes.
+type AbortMode int
+
+const (
+	// AbortNone never interrupts a running branch; the condition is only checked when the decorator starts.
+	AbortNone AbortMode = iota
+	// AbortSelf halts the decorated child and fails when the condition stops holding while it runs.
+	AbortSelf
+	// AbortLowerPriority halts the running lower-priority sibling in a Priority node when the condition
+	// changes from not holding to holding, so that the Priority node switches to the decorated branch.
+	// A condition that keeps holding, for example after the decorated child failed, aborts nothing.
+	AbortLowerPriority
+	// AbortBoth combines AbortSelf and AbortLowerPriority.
+	AbortBoth
+)
+
+// String returns the name of the abort mode.
+func (m AbortMode) String() string {
+	switch m {
+	case AbortSelf:
+		return "Self"
+	case AbortLowerPriority:
+		return "LowerPriority"
+	case AbortBoth:
+		return "Both"
+	default:
+		return "None"
+	}
+}
+
+// Aborter is implemented by nodes that can interrupt the running lower-priority sibling of a Priority
+// node. While a child of a Priority node is running, the Priority node asks every higher-priority child
+// implementing Aborter on each tick, and switches to the first one requesting an abort. An Aborter should
+// request an abort when its condition changes, not on every tick it holds, so that a higher-priority
+// branch that failed does not restart the running one over and over.
+type Aborter[T any] interface {
+	// AbortsLowerPriority reports whether the running lower-priority sibling must be halted.
+	AbortsLowerPriority(object T) bool
+}
+
+// ConditionalDecorator is a decorator node that guards its child node with a condition, in the style of
+// Unreal Engine blackboard decorators. The child only runs if the condition holds; otherwise the decorator
+// fails. The abort mode decides whether the condition keeps being observed once a branch is running.
+type ConditionalDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	// Condition decides whether the child node may run. It receives the decorator, giving access to
+	// its blackboard, and the object of the tree.
+	Condition func(decorator *ConditionalDecorator[T], object T) bool
+
+	Abort       AbortMode // Selects which running branches are interrupted when the condition changes.
+	NodeRunning bool      // Indicates whether the child node is currently running.
+	Held        bool      // The value of the condition when it was last checked.
+}
+
+// NewConditionalDecorator creates a new ConditionalDecorator guarding the specified child node with
+// the condition and observing it according to the abort mode.
+func NewConditionalDecorator[T any](node Node[T], condition func(decorator *ConditionalDecorator[T], object T) bool, abort AbortMode) *ConditionalDecorator[T] {
+	decorator := &ConditionalDecorator[T]{
+		Condition: condition,
+		Abort:     abort,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator and its child node unless the child node is running.
+func (d *ConditionalDecorator[T]) Start(object T) {
+	if !d.NodeRunning {
+		d.setObject(object)
+		d.Node.Start(object)
+	}
+}
+
+// Run checks the condition and runs the child node. A running child node is halted if the condition
+// no longer holds and the decorator aborts itself.
+func (d *ConditionalDecorator[T]) Run(object T) {
+	if !d.NodeRunning || d.Abort == AbortSelf || d.Abort == AbortBoth {
+		d.Held = d.Condition(d, object)
+		if !d.Held {
+			if d.NodeRunning {
+				Halt(d.Node, object)
+			}
+			d.NodeRunning = false
+			if d.ControlNode != nil {
+				d.ControlNode.Fail()
+			}
+			return
+		}
+	}
+	d.Node.Run(object)
+}
+
+// AbortsLowerPriority reports whether the decorator observes lower-priority branches and its condition
+// has started holding since it was last checked.
+func (d *ConditionalDecorator[T]) AbortsLowerPriority(object T) bool {
+	if d.Abort != AbortLowerPriority && d.Abort != AbortBoth {
+		return false
+	}
+	held := d.Held
+	d.Held = d.Condition(d, object)
+	return d.Held && !held
+}
+
+// Running signals that the child node is still running. It notifies the control node, if present.
+func (d *ConditionalDecorator[T]) Running() {
+	d.NodeRunning = true
+	if d.ControlNode != nil {
+		d.ControlNode.Running()
+	}
+}
+
+// Success is called when the child node succeeds. It signals success to the control node.
+func (d *ConditionalDecorator[T]) Success() {
+	d.NodeRunning = false
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails. It signals failure to the control node.
+func (d *ConditionalDecorator[T]) Fail() {
+	d.NodeRunning = false
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// Halt interrupts the running child node.
+func (d *ConditionalDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+}
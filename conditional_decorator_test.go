@@ -0,0 +1,162 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// flag returns a condition reporting the value pointed to by holds.
+func flag(holds *bool) func(decorator *ConditionalDecorator[int], obj int) bool {
+	return func(decorator *ConditionalDecorator[int], obj int) bool {
+		return *holds
+	}
+}
+
+func TestAbortMode_String(t *testing.T) {
+	cases := map[AbortMode]string{
+		AbortNone:          "None",
+		AbortSelf:          "Self",
+		AbortLowerPriority: "LowerPriority",
+		AbortBoth:          "Both",
+		AbortMode(42):      "None",
+	}
+	for mode, expected := range cases {
+		if mode.String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, mode.String())
+		}
+	}
+}
+
+func TestConditionalDecorator_Guards(t *testing.T) {
+	holds := false
+	child := newHaltableTask(Success)
+	decorator := NewConditionalDecorator[int](child, flag(&holds), AbortNone)
+
+	if status := TickNode[int](decorator, 0); status != Failure || child.runs != 0 {
+		t.Errorf("Expected Failure without running the child, but got %v after %d runs", status, child.runs)
+	}
+	holds = true
+	if status := TickNode[int](decorator, 0); status != Success || child.runs != 1 {
+		t.Errorf("Expected the child to run and succeed, but got %v after %d runs", status, child.runs)
+	}
+
+	failing := NewConditionalDecorator[int](statusTask(Failure), flag(&holds), AbortNone)
+	if status := TickNode[int](failing, 0); status != Failure {
+		t.Errorf("Expected the child failure to be reported, but got %v", status)
+	}
+}
+
+func TestConditionalDecorator_AbortSelf(t *testing.T) {
+	for _, mode := range []AbortMode{AbortNone, AbortSelf, AbortBoth, AbortLowerPriority} {
+		holds := true
+		child := newHaltableTask(Running)
+		decorator := NewConditionalDecorator[int](child, flag(&holds), mode)
+
+		TickNode[int](decorator, 0)
+		holds = false
+		status := TickNode[int](decorator, 0)
+
+		aborts := mode == AbortSelf || mode == AbortBoth
+		if aborts && (status != Failure || child.halted != 1 || decorator.NodeRunning) {
+			t.Errorf("%v: expected the child to be halted and the decorator to fail, but got %v after %d halts", mode, status, child.halted)
+		}
+		if !aborts && (status != Running || child.halted != 0 || child.started != 1) {
+			t.Errorf("%v: expected the child to keep running, but got %v after %d halts", mode, status, child.halted)
+		}
+	}
+}
+
+func TestConditionalDecorator_Halt(t *testing.T) {
+	holds := true
+	child := newHaltableTask(Running)
+	decorator := NewConditionalDecorator[int](child, flag(&holds), AbortNone)
+
+	decorator.Halt(0)
+	TickNode[int](decorator, 0)
+	decorator.Halt(0)
+	if child.halted != 1 || decorator.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+}
+
+func TestPriority_AbortLowerPriority(t *testing.T) {
+	for _, mode := range []AbortMode{AbortNone, AbortSelf, AbortLowerPriority, AbortBoth} {
+		intruder := false
+		chase := newHaltableTask(Success)
+		patrol := newHaltableTask(Running)
+		guard := NewConditionalDecorator[int](chase, flag(&intruder), mode)
+		tree := NewBehaviorTree[int](NewPriority([]Node[int]{guard, patrol}))
+
+		first := tree.Tick(0)
+		intruder = true
+		second := tree.Tick(0)
+
+		aborts := mode == AbortLowerPriority || mode == AbortBoth
+		if aborts && (!reflect.DeepEqual([]Status{first, second}, []Status{Running, Success}) || patrol.halted != 1 || chase.runs != 1) {
+			t.Errorf("%v: expected the patrol to be halted for the chase, got %v, %v with %d halts", mode, first, second, patrol.halted)
+		}
+		if !aborts && (second != Running || patrol.halted != 0 || chase.runs != 0) {
+			t.Errorf("%v: expected the patrol to keep running, got %v with %d halts", mode, second, patrol.halted)
+		}
+	}
+}
+
+func TestPriority_AbortLowerPriorityOnChange(t *testing.T) {
+	intruder := true
+	chase := newHaltableTask(Failure)
+	setup := newHaltableTask(Success)
+	patrol := newHaltableTask(Running)
+	guard := NewConditionalDecorator[int](chase, flag(&intruder), AbortLowerPriority)
+	tree := NewBehaviorTree[int](NewPriority([]Node[int]{guard, NewSequence([]Node[int]{setup, patrol})}))
+
+	for tick := 0; tick < 5; tick++ {
+		if status := tree.Tick(0); status != Running {
+			t.Fatalf("Expected the patrol to run, but got %v on tick %d", status, tick)
+		}
+	}
+	if chase.runs != 1 || setup.runs != 1 || patrol.runs != 5 || patrol.halted != 0 {
+		t.Errorf("Expected a failed chase whose condition still holds not to restart the patrol, got %d chases, %d setups and %d halts", chase.runs, setup.runs, patrol.halted)
+	}
+
+	intruder = false
+	tree.Tick(0)
+	intruder = true
+	tree.Tick(0)
+	if chase.runs != 2 || patrol.halted != 1 || setup.runs != 2 {
+		t.Errorf("Expected the condition starting to hold again to abort the patrol once, got %d chases, %d halts and %d setups", chase.runs, patrol.halted, setup.runs)
+	}
+}
+
+func TestPriority_AbortLowerPriorityTraced(t *testing.T) {
+	intruder := false
+	patrol := newHaltableTask(Running)
+	guard := NewConditionalDecorator[int](statusTask(Running), flag(&intruder), AbortLowerPriority)
+	tree := NewBehaviorTree[int](NewPriority([]Node[int]{NewSequence([]Node[int]{statusTask(Failure)}), guard, patrol}))
+	tree.AddListener(NewRingBuffer[int](10))
+
+	tree.Tick(0)
+	intruder = true
+	if status := tree.Tick(0); status != Running || patrol.halted != 1 {
+		t.Errorf("Expected the traced guard to abort the patrol, but got %v with %d halts", status, patrol.halted)
+	}
+	if status := tree.Tick(0); status != Running || patrol.halted != 1 {
+		t.Errorf("Expected the guard branch to keep running, but got %v with %d halts", status, patrol.halted)
+	}
+}
+
+func TestConditionalDecorator_Blackboard(t *testing.T) {
+	alert := NewKey[bool]("alert")
+	decorator := NewConditionalDecorator[int](statusTask(Success), func(decorator *ConditionalDecorator[int], obj int) bool {
+		value, _ := alert.Get(decorator.Blackboard())
+		return value
+	}, AbortNone)
+	tree := NewBehaviorTree[int](decorator)
+
+	if status := tree.Tick(0); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+	alert.Set(tree.Blackboard(), true)
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+}
@@ -0,0 +1,83 @@
+package behaviortree
+
+import "time"
+
+// CooldownDecorator is a decorator node that keeps its child node from running again too soon. After the
+// child node completes, the decorator fails immediately without running it until the cooldown has passed.
+// The time is measured with Clock. The cooldown is kept by the decorator, so every tree built with its
+// own decorator has its own cooldown.
+type CooldownDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	Cooldown time.Duration // The time to wait after the child node completes before it may run again.
+	Clock    Clock         // Measures the time. If nil, the system clock is used.
+
+	ReadyAt     time.Time // The time at which the child node may run again.
+	NodeRunning bool      // Indicates whether the child node has been started and has not completed yet.
+}
+
+// NewCooldownDecorator creates a new CooldownDecorator that waits for the given cooldown after the specified
+// child node completes before running it again.
+func NewCooldownDecorator[T any](node Node[T], cooldown time.Duration) *CooldownDecorator[T] {
+	decorator := &CooldownDecorator[T]{
+		Cooldown: cooldown,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless the child node is running.
+func (d *CooldownDecorator[T]) Start(object T) {
+	if !d.NodeRunning {
+		d.setObject(object)
+	}
+}
+
+// Run executes the child node, starting it first if it is not running. While the cooldown is active, the
+// decorator fails without running the child node.
+func (d *CooldownDecorator[T]) Run(object T) {
+	if !d.NodeRunning {
+		if clockOrSystem(d.Clock).Now().Before(d.ReadyAt) {
+			if d.ControlNode != nil {
+				d.ControlNode.Fail()
+			}
+			return
+		}
+		d.NodeRunning = true
+		d.Node.Start(object)
+	}
+	d.Node.Run(object)
+}
+
+// Success is called when the child node succeeds. It starts the cooldown and signals success to the
+// control node.
+func (d *CooldownDecorator[T]) Success() {
+	d.complete()
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails. It starts the cooldown and signals failure to the control node.
+func (d *CooldownDecorator[T]) Fail() {
+	d.complete()
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// complete finishes the child node and starts the cooldown.
+func (d *CooldownDecorator[T]) complete() {
+	d.NodeRunning = false
+	d.ReadyAt = clockOrSystem(d.Clock).Now().Add(d.Cooldown)
+	d.Node.Finish(d.Object)
+}
+
+// Halt interrupts the running child node. An interrupted child node does not start the cooldown.
+func (d *CooldownDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+}
@@ -0,0 +1,81 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCooldownDecorator(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	child := newHaltableTask(Running, Success, Failure, Success)
+	cooldown := NewCooldownDecorator[int](child, time.Second)
+	cooldown.Clock = clock
+	tree := NewBehaviorTree[int](cooldown)
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0), tree.Tick(0)}
+	clock.Advance(time.Second)
+	statuses = append(statuses, tree.Tick(0), tree.Tick(0))
+	clock.Advance(time.Second)
+	statuses = append(statuses, tree.Tick(0))
+
+	expected := []Status{Running, Success, Failure, Failure, Failure, Success}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.runs != 4 {
+		t.Errorf("Expected the child to be skipped during the cooldown, but it ran %d times", child.runs)
+	}
+}
+
+func TestCooldownDecorator_WithoutControl(t *testing.T) {
+	cooldown := NewCooldownDecorator[int](statusTask(Success, Failure), time.Hour)
+	cooldown.Start(0)
+	cooldown.Run(0)
+	cooldown.Run(0)
+	cooldown.Fail()
+	if cooldown.NodeRunning || cooldown.ReadyAt.IsZero() {
+		t.Error("Expected the cooldown to start without a control node")
+	}
+}
+
+func TestCooldownDecorator_Halt(t *testing.T) {
+	child := newHaltableTask(Running, Success)
+	cooldown := NewCooldownDecorator[int](child, time.Hour)
+
+	TickNode[int](cooldown, 0)
+	cooldown.Halt(0)
+	cooldown.Halt(0)
+	if child.halted != 1 || cooldown.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+	if status := TickNode[int](cooldown, 0); status != Success {
+		t.Errorf("Expected a halted child not to start the cooldown, but got %v", status)
+	}
+}
+
+func TestRegistry_Cooldown(t *testing.T) {
+	registry := newTestRegistry()
+
+	node, err := registry.Build(Definition{Type: "Cooldown", Params: Params{"cooldown": "3s"}, Children: []Definition{{Type: "Succeed"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cooldown := node.(*CooldownDecorator[int]); cooldown.Cooldown != 3*time.Second {
+		t.Errorf("Expected a cooldown of 3s, but got %v", cooldown.Cooldown)
+	}
+	definition, err := registry.Describe(NewCooldownDecorator[int](node, time.Minute))
+	if err != nil || definition.Params["cooldown"] != "1m0s" {
+		t.Errorf("Expected the cooldown to be described, but got %+v (%v)", definition, err)
+	}
+
+	errorCases := []Definition{
+		{Type: "Cooldown"},
+		{Type: "Cooldown", Params: Params{"cooldown": 3}, Children: []Definition{{Type: "Succeed"}}},
+	}
+	for _, definition := range errorCases {
+		if _, err := registry.Build(definition); err == nil {
+			t.Errorf("Expected an error for %+v", definition)
+		}
+	}
+}
@@ -32,3 +32,23 @@ func (d *Decorator[T]) Finish(object T) {
 func (d *Decorator[T]) Run(object T) {
 	d.Node.Run(object)
 }
+
+// Children returns the decorated child node.
+func (d *Decorator[T]) Children() []Node[T] {
+	return []Node[T]{d.Node}
+}
+
+// Kind reports that the decorator is a decorator node.
+func (d *Decorator[T]) Kind() Kind {
+	return KindDecorator
+}
+
+// replaceChild replaces the decorated child node.
+func (d *Decorator[T]) replaceChild(i int, node Node[T]) {
+	d.Node = node
+}
+
+// Halt interrupts the decorated child node.
+func (d *Decorator[T]) Halt(object T) {
+	Halt(d.Node, object)
+}
@@ -0,0 +1,195 @@
+package behaviortree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Definition describes a node of a behavior tree independently of the Go code that builds it.
+// Definitions are produced by the loaders and turned into nodes by a Registry.
+type Definition struct {
+	Type     string       `json:"type"`               // The registered name of the node type.
+	Name     string       `json:"name,omitempty"`     // The optional name given to the node.
+	Params   Params       `json:"params,omitempty"`   // The parameters passed to the node factory.
+	Children []Definition `json:"children,omitempty"` // The definitions of the child nodes.
+}
+
+// Origin records the type and parameters a Registry built a node from, so that the registry does not
+// need to keep track of the nodes it builds. It is embedded by BaseNode and the built-in nodes, and can
+// be embedded in custom node types that do not embed BaseNode.
+type Origin struct {
+	definition *Definition // The type and parameters the node was built from, or nil.
+}
+
+// definitionHolder is implemented by nodes embedding Origin.
+type definitionHolder interface {
+	setDefinition(definition *Definition)
+	builtDefinition() *Definition
+}
+
+// setDefinition records the type and parameters the node was built from.
+func (o *Origin) setDefinition(definition *Definition) {
+	o.definition = definition
+}
+
+// builtDefinition returns the type and parameters the node was built from, or nil if it was not built
+// by a Registry.
+func (o *Origin) builtDefinition() *Definition {
+	return o.definition
+}
+
+// Params holds the parameters of a node definition. The accessors accept the values produced by the
+// loaders as well as native Go values, and return a ParamError if a value cannot be converted.
+type Params map[string]any
+
+// ParamError reports a parameter that could not be converted to the requested type.
+type ParamError struct {
+	Name string // The name of the parameter.
+	Err  error  // The conversion error.
+}
+
+// Error returns the description of the parameter error.
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("parameter %q: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// String returns the parameter as a string, or fallback if it is not set.
+func (p Params) String(name string, fallback string) (string, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fallback, p.invalid(name, "a string", value)
+}
+
+// Int returns the parameter as an integer, or fallback if it is not set.
+func (p Params) Int(name string, fallback int) (int, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), nil
+		}
+	case json.Number:
+		if n, err := strconv.Atoi(string(v)); err == nil {
+			return n, nil
+		}
+	case string:
+		if n, err := strconv.Atoi(v); err == nil {
+			return n, nil
+		}
+	}
+	return fallback, p.invalid(name, "an integer", value)
+}
+
+// Float returns the parameter as a floating-point number, or fallback if it is not set.
+func (p Params) Float(name string, fallback float64) (float64, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f, nil
+		}
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+	}
+	return fallback, p.invalid(name, "a number", value)
+}
+
+// Floats returns the parameter as a list of floating-point numbers, or fallback if it is not set. A list
+// can be given as an array of numbers or as a string of comma-separated numbers, such as "70,25,5".
+func (p Params) Floats(name string, fallback []float64) ([]float64, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	var items []any
+	switch v := value.(type) {
+	case []float64:
+		return v, nil
+	case []any:
+		items = v
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+	default:
+		return fallback, p.invalid(name, "a list of numbers", value)
+	}
+
+	floats := make([]float64, len(items))
+	for i, item := range items {
+		f, err := Params{name: item}.Float(name, 0)
+		if err != nil {
+			return fallback, p.invalid(name, "a list of numbers", value)
+		}
+		floats[i] = f
+	}
+	return floats, nil
+}
+
+// Bool returns the parameter as a boolean, or fallback if it is not set.
+func (p Params) Bool(name string, fallback bool) (bool, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b, nil
+		}
+	}
+	return fallback, p.invalid(name, "a boolean", value)
+}
+
+// Duration returns the parameter as a duration, or fallback if it is not set. String values use the
+// time.ParseDuration syntax, such as "1.5s" or "300ms".
+func (p Params) Duration(name string, fallback time.Duration) (time.Duration, error) {
+	value, ok := p[name]
+	if !ok {
+		return fallback, nil
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, nil
+		}
+	}
+	return fallback, p.invalid(name, `a duration such as "1.5s"`, value)
+}
+
+// invalid returns the error for a parameter value that cannot be converted.
+func (p Params) invalid(name, expected string, value any) error {
+	return &ParamError{Name: name, Err: fmt.Errorf("expected %s, got %v", expected, value)}
+}
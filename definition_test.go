@@ -0,0 +1,143 @@
+package behaviortree
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParams_String(t *testing.T) {
+	params := Params{"name": "Max", "count": 3}
+
+	if value, err := params.String("name", ""); err != nil || value != "Max" {
+		t.Errorf("Expected Max, but got %q (%v)", value, err)
+	}
+	if value, err := params.String("missing", "fallback"); err != nil || value != "fallback" {
+		t.Errorf("Expected fallback, but got %q (%v)", value, err)
+	}
+	if _, err := params.String("count", ""); err == nil {
+		t.Error("Expected an error for a non-string value")
+	}
+}
+
+func TestParams_Int(t *testing.T) {
+	params := Params{
+		"int":     4,
+		"float":   float64(5),
+		"number":  json.Number("6"),
+		"string":  "7",
+		"decimal": 1.5,
+		"bad":     "many",
+		"bool":    true,
+	}
+	expected := map[string]int{"int": 4, "float": 5, "number": 6, "string": 7, "missing": 9}
+	for name, want := range expected {
+		if value, err := params.Int(name, 9); err != nil || value != want {
+			t.Errorf("%s: expected %d, but got %d (%v)", name, want, value, err)
+		}
+	}
+	for _, name := range []string{"decimal", "bad", "bool"} {
+		if _, err := params.Int(name, 0); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParams_Float(t *testing.T) {
+	params := Params{
+		"float":  0.7,
+		"int":    2,
+		"number": json.Number("0.25"),
+		"string": "1.5",
+		"bad":    "high",
+		"bool":   false,
+	}
+	expected := map[string]float64{"float": 0.7, "int": 2, "number": 0.25, "string": 1.5, "missing": 3}
+	for name, want := range expected {
+		if value, err := params.Float(name, 3); err != nil || value != want {
+			t.Errorf("%s: expected %v, but got %v (%v)", name, want, value, err)
+		}
+	}
+	for _, name := range []string{"bad", "bool"} {
+		if _, err := params.Float(name, 0); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParams_Floats(t *testing.T) {
+	params := Params{
+		"floats": []float64{1, 2},
+		"array":  []any{json.Number("70"), 25, 5.5},
+		"string": "70, 25,5",
+		"bad":    "70,lots",
+		"item":   []any{true},
+		"int":    3,
+	}
+	expected := map[string][]float64{"floats": {1, 2}, "array": {70, 25, 5.5}, "string": {70, 25, 5}, "missing": {0}}
+	for name, want := range expected {
+		if value, err := params.Floats(name, []float64{0}); err != nil || !reflect.DeepEqual(value, want) {
+			t.Errorf("%s: expected %v, but got %v (%v)", name, want, value, err)
+		}
+	}
+	for _, name := range []string{"bad", "item", "int"} {
+		if _, err := params.Floats(name, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParams_Bool(t *testing.T) {
+	params := Params{"bool": true, "string": "false", "bad": "maybe", "int": 1}
+
+	if value, err := params.Bool("bool", false); err != nil || !value {
+		t.Errorf("Expected true, but got %v (%v)", value, err)
+	}
+	if value, err := params.Bool("string", true); err != nil || value {
+		t.Errorf("Expected false, but got %v (%v)", value, err)
+	}
+	if value, err := params.Bool("missing", true); err != nil || !value {
+		t.Errorf("Expected fallback, but got %v (%v)", value, err)
+	}
+	for _, name := range []string{"bad", "int"} {
+		if _, err := params.Bool(name, false); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParams_Duration(t *testing.T) {
+	params := Params{"native": time.Second, "string": "300ms", "bad": "soon", "number": 5}
+
+	if value, err := params.Duration("native", 0); err != nil || value != time.Second {
+		t.Errorf("Expected 1s, but got %v (%v)", value, err)
+	}
+	if value, err := params.Duration("string", 0); err != nil || value != 300*time.Millisecond {
+		t.Errorf("Expected 300ms, but got %v (%v)", value, err)
+	}
+	if value, err := params.Duration("missing", time.Minute); err != nil || value != time.Minute {
+		t.Errorf("Expected fallback, but got %v (%v)", value, err)
+	}
+	for _, name := range []string{"bad", "number"} {
+		if _, err := params.Duration(name, 0); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParamError(t *testing.T) {
+	_, err := Params{"count": "many"}.Int("count", 0)
+
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || paramErr.Name != "count" {
+		t.Fatalf("Expected a ParamError for count, but got %v", err)
+	}
+	if err.Error() != `parameter "count": expected an integer, got many` {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+	if errors.Unwrap(err) == nil {
+		t.Error("Expected ParamError to wrap the conversion error")
+	}
+}
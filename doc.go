@@ -33,6 +33,15 @@ Example Usage:
 		tree.Run(obj)
 	}
 
+Concurrency:
+
+A behavior tree and its nodes are not safe for concurrent use: each tree must be ticked, halted and
+modified by one goroutine at a time. Independent trees, such as the instances created with NewInstance,
+can be ticked in parallel by a Pool. State shared between such trees must then be safe for concurrent
+use: Blackboard, Library, RingBuffer, ManualClock, SystemClock, Pool and the log listeners are; a
+*rand.Rand is not, but instances get random sources of their own, seeded from the template's, and other
+listeners can be wrapped with NewSyncListener. Scheduler and Registry are not safe for concurrent use.
+
 Benchmark tests:
 	$ go test -bench=. -benchtime=5s -test.benchmem
 	BenchmarkSequence_Success-11                    282116228               21.77 ns/op            0 B/op          0 allocs/op
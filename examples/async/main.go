@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vkopitsa/behaviortree-go"
+)
+
+// Agent represents an entity that queries a slow remote service.
+type Agent struct {
+	Name   string
+	Orders int
+}
+
+// FetchOrders simulates a slow HTTP request that honours cancellation.
+func (a *Agent) FetchOrders(ctx context.Context) error {
+	select {
+	case <-time.After(200 * time.Millisecond):
+		a.Orders = 3
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func main() {
+	agent := &Agent{Name: "Courier"}
+
+	tree := behaviortree.NewBehaviorTree[*Agent](
+		behaviortree.NewSequence[*Agent]([]behaviortree.Node[*Agent]{
+			// The request runs in a goroutine while the tree keeps ticking.
+			behaviortree.NewAsyncTask[*Agent](func(ctx context.Context, agent *Agent) error {
+				fmt.Println(agent.Name, "fetches orders...")
+				return agent.FetchOrders(ctx)
+			}),
+			behaviortree.NewTask[*Agent](func(task *behaviortree.Task[*Agent], agent *Agent) {
+				fmt.Println(agent.Name, "delivers", agent.Orders, "orders.")
+				task.Success()
+			}),
+		}),
+	)
+
+	// Tick at 60Hz until the tree completes.
+	ticker := time.NewTicker(time.Second / 60)
+	defer ticker.Stop()
+	for tick := 1; ; tick++ {
+		<-ticker.C
+		if status := tree.Tick(agent); status != behaviortree.Running {
+			fmt.Printf("Tree finished with %v after %d ticks.\n", status, tick)
+			return
+		}
+	}
+}
@@ -56,6 +56,11 @@ func (d *GuardDog) DetectIntruder() bool {
 	return d.IntruderDetected
 }
 
+// HasIntruder reports whether the last look around detected an intruder, without looking again.
+func (d *GuardDog) HasIntruder() bool {
+	return d.IntruderDetected
+}
+
 // ChaseIntruder simulates the dog chasing the intruder.
 func (d *GuardDog) ChaseIntruder() bool {
 	d.HuntCount++
@@ -110,14 +115,8 @@ func main() {
 		behaviortree.NewSequence[*GuardDog]([]behaviortree.Node[*GuardDog]{
 			// Low Battery Sequence: Check battery and recharge if necessary
 			behaviortree.NewSequence[*GuardDog]([]behaviortree.Node[*GuardDog]{
-				// Task to check battery
-				behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
-					if dog.CheckBattery() {
-						task.Success()
-					} else {
-						task.Fail()
-					}
-				}),
+				// Condition to check battery
+				behaviortree.NewCondition[*GuardDog]((*GuardDog).CheckBattery),
 				// Task to recharge, wrapped with AlwaysSucceedDecorator
 				behaviortree.NewAlwaysSucceedDecorator[*GuardDog](
 					behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
@@ -126,11 +125,16 @@ func main() {
 					}),
 				),
 			}),
-			// Task to bark
-			behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
-				dog.Bark()
-				task.Success()
-			}),
+			// Task to bark, at most once every 3 seconds; skipping a bark does not fail the sequence
+			behaviortree.NewAlwaysSucceedDecorator[*GuardDog](
+				behaviortree.NewCooldownDecorator[*GuardDog](
+					behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
+						dog.Bark()
+						task.Success()
+					}),
+					3*time.Second,
+				),
+			),
 			// Main Priority Selector: Choose between handling intruders or patrolling
 			behaviortree.NewPriority[*GuardDog]([]behaviortree.Node[*GuardDog]{
 				// Patrol and Handle Sequence wrapped with Random Selector
@@ -149,15 +153,13 @@ func main() {
 					behaviortree.NewPriority[*GuardDog]([]behaviortree.Node[*GuardDog]{
 						// Handle Intruder with Retry Sequence
 						behaviortree.NewSequence[*GuardDog]([]behaviortree.Node[*GuardDog]{
-							// Task to detect intruder
+							// Task to look around, recording whether an intruder is seen
 							behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
-								detected := dog.DetectIntruder()
-								if detected {
-									task.Success()
-								} else {
-									task.Fail()
-								}
+								dog.DetectIntruder()
+								task.Success()
 							}),
+							// Condition to check the sighting
+							behaviortree.NewCondition[*GuardDog]((*GuardDog).HasIntruder),
 							// Decorator to keep chasing until intruder is caught
 							behaviortree.NewUntilFailDecorator[*GuardDog](
 								behaviortree.NewTask[*GuardDog](func(task *behaviortree.Task[*GuardDog], dog *GuardDog) {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/vkopitsa/behaviortree-go"
+)
+
+// Robot represents an agent whose behavior is loaded from JSON.
+type Robot struct {
+	Name         string
+	BatteryLevel int
+}
+
+// definition describes the behavior of the robot. Designers can edit it without recompiling.
+const definition = `{
+	"type": "Priority",
+	"children": [
+		{"type": "Sequence", "children": [
+			{"type": "Invert", "children": [{"type": "CheckBattery"}]},
+			{"type": "Recharge"}
+		]},
+		{"type": "Patrol"}
+	]
+}`
+
+func main() {
+	registry := behaviortree.NewRegistry[*Robot]()
+	registry.RegisterTask("CheckBattery", func(task *behaviortree.Task[*Robot], robot *Robot) {
+		if robot.BatteryLevel >= 20 {
+			task.Success()
+		} else {
+			task.Fail()
+		}
+	})
+	registry.RegisterTask("Recharge", func(task *behaviortree.Task[*Robot], robot *Robot) {
+		fmt.Printf("%s is recharging.\n", robot.Name)
+		robot.BatteryLevel = 100
+		task.Success()
+	})
+	registry.RegisterTask("Patrol", func(task *behaviortree.Task[*Robot], robot *Robot) {
+		robot.BatteryLevel -= 30
+		fmt.Printf("%s is patrolling (battery %d%%).\n", robot.Name, robot.BatteryLevel)
+		task.Success()
+	})
+
+	tree, err := registry.LoadJSON([]byte(definition))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	robot := &Robot{Name: "R2", BatteryLevel: 100}
+	for i := 0; i < 6; i++ {
+		fmt.Printf("\n-- Tick %d --\n", i+1)
+		fmt.Println("Tree status:", tree.Tick(robot))
+	}
+}
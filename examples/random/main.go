@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/vkopitsa/behaviortree-go"
@@ -33,6 +35,9 @@ func (d *RandomDog) WalkDown() {
 }
 
 func main() {
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed of the random walk, to replay a run")
+	flag.Parse()
+
 	// Define tasks.
 	looking := behaviortree.NewTask[*RandomDog](func(task *behaviortree.Task[*RandomDog], dog *RandomDog) {
 		dog.Bark()
@@ -68,6 +73,10 @@ func main() {
 	// Create the behavior tree.
 	tree := behaviortree.NewBehaviorTree[*RandomDog](sequence)
 
+	// Draw the random choices of the tree from the seed.
+	tree.SetRand(rand.New(rand.NewSource(*seed)))
+	fmt.Println("seed:", *seed)
+
 	// Create a Dog instance.
 	dog := &RandomDog{Name: "Frank"}
 
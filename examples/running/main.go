@@ -65,7 +65,8 @@ func main() {
 	// Run the behavior tree
 	for i := 0; i < 5; i++ {
 		fmt.Printf("\n-- Tick %d --\n", i+1)
-		tree.Run(dog)
+		status := tree.Tick(dog)
+		fmt.Println("Tree status:", status)
 		time.Sleep(500 * time.Millisecond) // Simulate time between ticks
 	}
 }
@@ -0,0 +1,79 @@
+package behaviortree
+
+// GuardDecorator is a decorator node that gates its child node on a guard. The guard is checked before
+// the child node starts; if it does not hold, the decorator fails without running the child node. If
+// Recheck is set, the guard is also checked on every tick while the child node runs, and the child node
+// is halted as soon as the guard stops holding. Unlike a condition placed before the child node in a
+// Sequence, the guard keeps being checked after the Sequence has moved on to the child node.
+type GuardDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	Guard       Checker[T] // Decides whether the child node may run, such as a Condition or a CheckerFunc.
+	Recheck     bool       // Indicates whether the guard is checked on every tick while the child node runs.
+	NodeRunning bool       // Indicates whether the child node has been started and has not completed yet.
+}
+
+// NewGuardDecorator creates a new GuardDecorator gating the specified child node on the guard. If recheck
+// is set, the guard is checked on every tick while the child node runs.
+func NewGuardDecorator[T any](node Node[T], guard Checker[T], recheck bool) *GuardDecorator[T] {
+	decorator := &GuardDecorator[T]{
+		Guard:   guard,
+		Recheck: recheck,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless the child node is running.
+func (d *GuardDecorator[T]) Start(object T) {
+	if !d.NodeRunning {
+		d.setObject(object)
+	}
+}
+
+// Run checks the guard and executes the child node, starting it first if it is not running. If the guard
+// does not hold, a running child node is halted and the decorator fails.
+func (d *GuardDecorator[T]) Run(object T) {
+	if (!d.NodeRunning || d.Recheck) && !d.Guard.Check(object) {
+		if d.NodeRunning {
+			d.NodeRunning = false
+			Halt(d.Node, object)
+		}
+		if d.ControlNode != nil {
+			d.ControlNode.Fail()
+		}
+		return
+	}
+	if !d.NodeRunning {
+		d.NodeRunning = true
+		d.Node.Start(object)
+	}
+	d.Node.Run(object)
+}
+
+// Success is called when the child node succeeds. It signals success to the control node.
+func (d *GuardDecorator[T]) Success() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails. It signals failure to the control node.
+func (d *GuardDecorator[T]) Fail() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// Halt interrupts the running child node.
+func (d *GuardDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+}
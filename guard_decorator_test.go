@@ -0,0 +1,75 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGuardDecorator_ChecksBeforeStarting(t *testing.T) {
+	open := false
+	child := newHaltableTask(Running, Success, Failure)
+	guard := NewGuardDecorator[int](child, CheckerFunc[int](func(obj int) bool { return open }), false)
+	tree := NewBehaviorTree[int](guard)
+
+	statuses := []Status{tree.Tick(0)}
+	open = true
+	statuses = append(statuses, tree.Tick(0))
+	open = false
+	statuses = append(statuses, tree.Tick(0))
+	open = true
+	statuses = append(statuses, tree.Tick(0))
+
+	if !reflect.DeepEqual(statuses, []Status{Failure, Running, Success, Failure}) {
+		t.Errorf("Expected Failure, Running, Success, Failure, but got %v", statuses)
+	}
+	if child.started != 2 || child.runs != 3 {
+		t.Errorf("Expected the guard to be checked only before starting, got %d starts and %d runs", child.started, child.runs)
+	}
+}
+
+func TestGuardDecorator_Recheck(t *testing.T) {
+	open := true
+	child := newHaltableTask(Running)
+	battery := NewCondition(func(obj int) bool { return open })
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{
+		statusTask(Success),
+		NewGuardDecorator[int](child, battery, true),
+	}))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0)}
+	open = false
+	statuses = append(statuses, tree.Tick(0))
+
+	if !reflect.DeepEqual(statuses, []Status{Running, Running, Failure}) {
+		t.Errorf("Expected Running, Running, Failure, but got %v", statuses)
+	}
+	if child.halted != 1 || child.runs != 2 {
+		t.Errorf("Expected the running child to be halted, got %d halts and %d runs", child.halted, child.runs)
+	}
+}
+
+func TestGuardDecorator_WithoutControl(t *testing.T) {
+	open := true
+	guard := NewGuardDecorator[int](statusTask(Running, Failure), CheckerFunc[int](func(obj int) bool { return open }), true)
+	guard.Start(0)
+	guard.Run(0)
+	guard.Run(0)
+	guard.Success()
+	open = false
+	guard.Run(0)
+	if guard.NodeRunning {
+		t.Error("Expected the child not to be running")
+	}
+}
+
+func TestGuardDecorator_Halt(t *testing.T) {
+	child := newHaltableTask(Running)
+	guard := NewGuardDecorator[int](child, CheckerFunc[int](func(obj int) bool { return true }), false)
+
+	TickNode[int](guard, 0)
+	guard.Halt(0)
+	guard.Halt(0)
+	if child.halted != 1 || guard.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+}
@@ -0,0 +1,19 @@
+package behaviortree
+
+// Halter is implemented by nodes that can be interrupted while they are running. Halting a node stops
+// its work, halts its running descendants and resets it, so that it starts over the next time it runs.
+// The built-in composites, decorators, asynchronous tasks and behavior trees implement it.
+type Halter[T any] interface {
+	// Halt interrupts the node with the given object.
+	Halt(object T)
+}
+
+// Halt interrupts the node. Nodes implementing Halter are halted; other nodes are finished, which lets
+// custom nodes written before the halt protocol release their resources.
+func Halt[T any](node Node[T], object T) {
+	if halter, ok := node.(Halter[T]); ok {
+		halter.Halt(object)
+		return
+	}
+	node.Finish(object)
+}
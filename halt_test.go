@@ -0,0 +1,188 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// haltableTask is a task reporting the given statuses that counts how often it is started, run and halted.
+type haltableTask struct {
+	*Task[int]
+	started, runs, halted int
+}
+
+func newHaltableTask(statuses ...Status) *haltableTask {
+	h := &haltableTask{}
+	h.Task = statusTask(statuses...)
+	run := h.Task.RunFunc
+	h.Task.RunFunc = func(task *Task[int], obj int) {
+		h.runs++
+		run(task, obj)
+	}
+	return h
+}
+
+func (h *haltableTask) Start(obj int) {
+	h.started++
+}
+
+func (h *haltableTask) Halt(obj int) {
+	h.halted++
+}
+
+func TestHalt_FallsBackToFinish(t *testing.T) {
+	mockNode := NewMockNode[int](t)
+	Halt[int](mockNode, 0)
+	if !mockNode.FinishCalled {
+		t.Error("Expected a node without Halt to be finished")
+	}
+
+	task := newHaltableTask(Running)
+	Halt[int](task, 0)
+	if task.halted != 1 {
+		t.Errorf("Expected the node to be halted once, but got %d", task.halted)
+	}
+}
+
+func TestSequence_ResumesRunningChild(t *testing.T) {
+	first, second := newHaltableTask(Success), newHaltableTask(Running, Success)
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{first, second}))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+		t.Errorf("Expected Running then Success, but got %v", statuses)
+	}
+	if first.runs != 1 || second.runs != 2 || second.started != 1 {
+		t.Errorf("Expected the sequence to resume at the running child, got %d/%d runs and %d starts", first.runs, second.runs, second.started)
+	}
+}
+
+func TestPriority_ResumesRunningChild(t *testing.T) {
+	first, second := newHaltableTask(Failure), newHaltableTask(Running, Success)
+	tree := NewBehaviorTree[int](NewPriority([]Node[int]{first, second}))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+		t.Errorf("Expected Running then Success, but got %v", statuses)
+	}
+	if first.runs != 1 || second.runs != 2 || second.started != 1 {
+		t.Errorf("Expected the Priority node to resume at the running child, got %d/%d runs and %d starts", first.runs, second.runs, second.started)
+	}
+}
+
+func TestComposites_Halt(t *testing.T) {
+	cases := []struct {
+		name    string
+		compose func(running Node[int]) Node[int]
+	}{
+		{"Sequence", func(running Node[int]) Node[int] {
+			return NewSequence([]Node[int]{statusTask(Success), running})
+		}},
+		{"Priority", func(running Node[int]) Node[int] {
+			return NewPriority([]Node[int]{statusTask(Failure), running})
+		}},
+		{"Random", func(running Node[int]) Node[int] { return NewRandom([]Node[int]{running}) }},
+		{"Parallel", func(running Node[int]) Node[int] {
+			return NewParallel([]Node[int]{statusTask(Success), running}, RequireAll, RequireOne)
+		}},
+		{"Decorator", func(running Node[int]) Node[int] { return NewInvertDecorator[int](running) }},
+		{"BehaviorTree", func(running Node[int]) Node[int] { return NewBehaviorTree[int](running) }},
+	}
+	for _, c := range cases {
+		running := newHaltableTask(Running, Success)
+		tree := NewBehaviorTree[int](c.compose(running))
+
+		if status := tree.Tick(0); status != Running {
+			t.Fatalf("%s: expected Running, but got %v", c.name, status)
+		}
+		tree.Halt(0)
+		if running.halted != 1 {
+			t.Errorf("%s: expected the running child to be halted once, but got %d", c.name, running.halted)
+		}
+		tree.Halt(0)
+		if running.halted != 1 {
+			t.Errorf("%s: expected halting a stopped tree to have no effect, but got %d", c.name, running.halted)
+		}
+
+		if status := tree.Tick(0); status == Invalid {
+			t.Errorf("%s: expected the halted tree to run again, but got %v", c.name, status)
+		}
+		if running.started < 2 {
+			t.Errorf("%s: expected the halted child to be started again, but got %d starts", c.name, running.started)
+		}
+	}
+}
+
+func TestComposites_HaltIdle(t *testing.T) {
+	task := newHaltableTask(Success)
+	nodes := []Halter[int]{
+		NewSequence([]Node[int]{task}),
+		NewPriority([]Node[int]{task}),
+		NewRandom([]Node[int]{task}),
+		NewParallel([]Node[int]{task}, RequireAll, RequireOne),
+	}
+	for _, node := range nodes {
+		node.Halt(0)
+	}
+	if task.halted != 0 {
+		t.Errorf("Expected idle composites not to halt their children, but got %d", task.halted)
+	}
+}
+
+func TestBehaviorTree_RunStartsOnlyIdleRoot(t *testing.T) {
+	root := newHaltableTask(Running, Running, Success)
+	tree := NewBehaviorTree[int](root)
+
+	tree.Tick(0)
+	tree.Tick(0)
+	if root.started != 1 {
+		t.Errorf("Expected the running root to be started once, but got %d", root.started)
+	}
+	tree.Tick(0)
+	tree.Tick(0)
+	if root.started != 2 {
+		t.Errorf("Expected the completed root to be started again, but got %d", root.started)
+	}
+}
+
+func TestTracedNode_Halt(t *testing.T) {
+	running := newHaltableTask(Running)
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{running}))
+	buffer := NewRingBuffer[int](100)
+	tree.AddListener(buffer)
+
+	tree.Tick(0)
+	tree.Halt(0)
+
+	names := eventNames(buffer.Events())
+	expected := []string{"Sequence:Halt", "haltableTask:Halt"}
+	if !reflect.DeepEqual(names[len(names)-2:], expected) {
+		t.Errorf("Expected halt events %v, but got %v", expected, names)
+	}
+	if running.halted != 1 {
+		t.Errorf("Expected the traced node to be halted, but got %d", running.halted)
+	}
+	if EventHalt.String() != "Halt" {
+		t.Errorf("Unexpected name %q", EventHalt.String())
+	}
+}
+
+func TestComposites_StartKeepsRunningChild(t *testing.T) {
+	composites := map[string]func(nodes []Node[int]) Node[int]{
+		"Sequence": func(nodes []Node[int]) Node[int] { return NewSequence(nodes) },
+		"Priority": func(nodes []Node[int]) Node[int] { return NewPriority(nodes) },
+		"Random":   func(nodes []Node[int]) Node[int] { return NewRandom(nodes) },
+	}
+	for name, compose := range composites {
+		running := newHaltableTask(Running, Success)
+		node := compose([]Node[int]{running})
+
+		statuses := []Status{TickNode(node, 0), TickNode(node, 0)}
+		if !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+			t.Errorf("%s: expected Running then Success, but got %v", name, statuses)
+		}
+		if running.started != 1 {
+			t.Errorf("%s: expected the running child to be started once, but got %d", name, running.started)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package behaviortree
+
+import "strconv"
+
+// Identifier is implemented by nodes that carry a name and an ID. All built-in nodes implement it,
+// as do custom nodes embedding BaseNode, BranchNode or Decorator. Tools such as renderers, loaders
+// and tracers use it to tell which node did what.
+type Identifier interface {
+	// Name returns the name given to the node, or an empty string if it has none.
+	Name() string
+
+	// SetName gives the node a name.
+	SetName(name string)
+
+	// ID returns the path of the node in its tree, such as "root/0/2", or an empty string if the
+	// node was not assigned an ID.
+	ID() string
+
+	// SetID assigns the path of the node in its tree.
+	SetID(id string)
+}
+
+// Identity provides a default implementation of the Identifier interface. It can be embedded in
+// custom node types that do not embed BaseNode.
+type Identity struct {
+	name string // The optional name of the node.
+	id   string // The path of the node in its tree.
+}
+
+// Name returns the name given to the node, or an empty string if it has none.
+func (i *Identity) Name() string {
+	return i.name
+}
+
+// SetName gives the node a name.
+func (i *Identity) SetName(name string) {
+	i.name = name
+}
+
+// ID returns the path of the node in its tree, or an empty string if it was not assigned one.
+func (i *Identity) ID() string {
+	return i.id
+}
+
+// SetID assigns the path of the node in its tree.
+func (i *Identity) SetID(id string) {
+	i.id = id
+}
+
+// Named gives the node a name and returns it, so that names can be set in nested constructors:
+//
+//	tree := behaviortree.NewBehaviorTree(behaviortree.NewSequence([]behaviortree.Node[*Dog]{
+//		behaviortree.Named(behaviortree.NewTask(bark), "Bark"),
+//	}))
+func Named[N interface{ SetName(name string) }](node N, name string) N {
+	node.SetName(name)
+	return node
+}
+
+// NameOf returns the name of the node if it has one, and the name of its type otherwise.
+func NameOf[T any](node Node[T]) string {
+	if identifier, ok := node.(Identifier); ok && identifier.Name() != "" {
+		return identifier.Name()
+	}
+	return TypeName(node)
+}
+
+// IDOf returns the ID of the node, or an empty string if it has none.
+func IDOf[T any](node Node[T]) string {
+	if identifier, ok := node.(Identifier); ok {
+		return identifier.ID()
+	}
+	return ""
+}
+
+// AssignIDs assigns path IDs to the node and its descendants. The node is assigned id and each
+// child is assigned the ID of its parent followed by a slash and its index, such as "root/0/2".
+// Nodes that do not implement Identifier keep no ID, but their descendants are assigned one.
+func AssignIDs[T any](node Node[T], id string) {
+	if identifier, ok := node.(Identifier); ok {
+		identifier.SetID(id)
+	}
+	for i, child := range ChildrenOf(node) {
+		if child != nil {
+			AssignIDs(child, id+"/"+strconv.Itoa(i))
+		}
+	}
+}
+
+// describeNode returns a description of the node for error messages, such as `"Bark" at root/0/1`.
+func describeNode[T any](node Node[T]) string {
+	description := strconv.Quote(NameOf(node))
+	if id := IDOf(node); id != "" {
+		description += " at " + id
+	}
+	return description
+}
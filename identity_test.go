@@ -0,0 +1,111 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIdentity(t *testing.T) {
+	var identity Identity
+	identity.SetName("Bark")
+	identity.SetID("root/1")
+
+	if identity.Name() != "Bark" || identity.ID() != "root/1" {
+		t.Errorf("Expected Bark at root/1, but got %q at %q", identity.Name(), identity.ID())
+	}
+}
+
+func TestIdentity_BuiltinNodes(t *testing.T) {
+	task := statusTask(Success)
+	nodes := []Node[int]{
+		task,
+		NewSequence([]Node[int]{task}),
+		NewPriority([]Node[int]{task}),
+		NewRandom([]Node[int]{task}),
+		NewParallel([]Node[int]{task}, RequireAll, RequireOne),
+		NewInvertDecorator[int](task),
+		NewAlwaysSucceedDecorator[int](task),
+		NewAlwaysFailDecorator[int](task),
+		NewUntilFailDecorator[int](task),
+		NewScopeDecorator[int](task),
+		NewBehaviorTree[int](task),
+	}
+	for _, node := range nodes {
+		if _, ok := node.(Identifier); !ok {
+			t.Errorf("Expected %T to implement Identifier", node)
+		}
+	}
+}
+
+func TestNamed(t *testing.T) {
+	task := Named(statusTask(Success), "Bark")
+
+	if task.Name() != "Bark" {
+		t.Errorf("Expected Bark, but got %q", task.Name())
+	}
+	if name := NameOf[int](task); name != "Bark" {
+		t.Errorf("Expected NameOf to return Bark, but got %q", name)
+	}
+	if name := NameOf[int](statusTask(Success)); name != "Task" {
+		t.Errorf("Expected NameOf to fall back to the type name, but got %q", name)
+	}
+	if name := NameOf[int](NewMockNode[int](t)); name != "MockNode" {
+		t.Errorf("Expected NameOf to fall back to the type name, but got %q", name)
+	}
+}
+
+func TestNewBehaviorTree_AssignsIDs(t *testing.T) {
+	a, b, c, d := statusTask(Success), statusTask(Success), statusTask(Success), statusTask(Success)
+	invert := NewInvertDecorator[int](b)
+	inner := NewBehaviorTree[int](NewSequence([]Node[int]{c, d}))
+	custom := &parentNode{nodes: []Node[int]{inner, nil}}
+	root := NewPriority([]Node[int]{NewSequence([]Node[int]{a, invert}), custom})
+	tree := NewBehaviorTree[int](root)
+
+	ids := map[string]Node[int]{
+		"root":         root,
+		"root/0/0":     a,
+		"root/0/1":     invert,
+		"root/0/1/0":   b,
+		"root/1/0":     inner,
+		"root/1/0/0/1": d,
+	}
+	for id, node := range ids {
+		if IDOf(node) != id {
+			t.Errorf("Expected %s to have ID %q, but got %q", TypeName(node), id, IDOf(node))
+		}
+	}
+	if IDOf[int](tree) != "" || IDOf[int](custom) != "" {
+		t.Error("Expected the tree and nodes without Identifier to have no ID")
+	}
+
+	inner.SetID("nested")
+	inner.AssignIDs()
+	if IDOf[int](d) != "nested/0/1" {
+		t.Errorf("Expected a nested tree to prefix IDs with its own ID, but got %q", IDOf[int](d))
+	}
+}
+
+func TestRegistry_Names(t *testing.T) {
+	registry := newTestRegistry()
+	definition := Definition{Type: "Sequence", Name: "patrol", Children: []Definition{
+		{Type: "Succeed", Name: "check"},
+		{Type: "Invert", Children: []Definition{{Type: "Fail"}}},
+	}}
+
+	node, err := registry.Build(definition)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name := NameOf(node); name != "patrol" {
+		t.Errorf("Expected the built node to be named patrol, but got %q", name)
+	}
+
+	described, err := registry.Describe(node)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(described, definition) {
+		t.Errorf("Expected names to be described:\n%+v\n%+v", described, definition)
+	}
+}
@@ -0,0 +1,106 @@
+package behaviortree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ParseJSON parses a JSON document describing a node definition. Each node is an object with a "type"
+// string, an optional "name" string, an optional "params" object and an optional "children" array of nodes:
+//
+//	{"type": "Sequence", "name": "Patrol", "children": [
+//		{"type": "CheckBattery"},
+//		{"type": "Parallel", "params": {"success": 1}, "children": [...]}
+//	]}
+//
+// Errors are reported as a *LoadError pointing at the offending part of the document.
+func ParseJSON(data []byte) (Definition, error) {
+	if err := json.Unmarshal(data, new(json.RawMessage)); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			err = fmt.Errorf("%w (offset %d)", err, syntaxErr.Offset)
+		}
+		return Definition{}, &LoadError{Path: "$", Err: err}
+	}
+	return parseJSONDefinition(data, "$")
+}
+
+// LoadJSON builds a behavior tree from a JSON document as described by ParseJSON.
+func (r *Registry[T]) LoadJSON(data []byte) (*BehaviorTree[T], error) {
+	definition, err := ParseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	root, err := r.Build(definition)
+	if err != nil {
+		return nil, err
+	}
+	return NewBehaviorTree(root), nil
+}
+
+// parseJSONDefinition parses the node located at path.
+func parseJSONDefinition(data json.RawMessage, path string) (Definition, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Definition{}, &LoadError{Path: path, Err: errors.New("expected a node object")}
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var definition Definition
+	for _, key := range keys {
+		value := fields[key]
+		var err error
+		switch key {
+		case "type":
+			err = json.Unmarshal(value, &definition.Type)
+		case "name":
+			err = json.Unmarshal(value, &definition.Name)
+		case "params":
+			decoder := json.NewDecoder(bytes.NewReader(value))
+			decoder.UseNumber()
+			err = decoder.Decode(&definition.Params)
+		case "children":
+			definition.Children, err = parseJSONChildren(value, path+".children")
+			if err != nil {
+				return Definition{}, err
+			}
+		default:
+			err = errors.New("unknown field")
+		}
+		if err != nil {
+			return Definition{}, &LoadError{Path: path + "." + key, Err: err}
+		}
+	}
+
+	if definition.Type == "" {
+		return Definition{}, &LoadError{Path: path + ".type", Err: errors.New("missing node type")}
+	}
+	return definition, nil
+}
+
+// parseJSONChildren parses the array of child nodes located at path.
+func parseJSONChildren(data json.RawMessage, path string) ([]Definition, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, &LoadError{Path: path, Err: errors.New("expected an array of nodes")}
+	}
+
+	children := make([]Definition, 0, len(items))
+	for i, item := range items {
+		child, err := parseJSONDefinition(item, path+"["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
@@ -0,0 +1,98 @@
+package behaviortree
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseJSON(t *testing.T) {
+	definition, err := ParseJSON([]byte(`{
+		"type": "Sequence",
+		"children": [
+			{"type": "CheckBattery", "name": "battery"},
+			{"type": "Parallel", "params": {"success": 1, "name": "scan"}, "children": []}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if definition.Type != "Sequence" || len(definition.Children) != 2 {
+		t.Fatalf("Unexpected definition: %+v", definition)
+	}
+	parallel := definition.Children[1]
+	if success, err := parallel.Params.Int("success", 0); err != nil || success != 1 {
+		t.Errorf("Expected success param to be 1, but got %d (%v)", success, err)
+	}
+	if name, _ := parallel.Params.String("name", ""); name != "scan" {
+		t.Errorf("Expected name param to be scan, but got %q", name)
+	}
+	if !reflect.DeepEqual(definition.Children[0], Definition{Type: "CheckBattery", Name: "battery", Children: nil}) {
+		t.Errorf("Unexpected leaf definition: %+v", definition.Children[0])
+	}
+}
+
+func TestParseJSON_Errors(t *testing.T) {
+	cases := []struct {
+		document string
+		path     string
+		message  string
+	}{
+		{`{"type": "Sequence",`, "$", "behaviortree: $: unexpected end of JSON input (offset 20)"},
+		{`[]`, "$", "behaviortree: $: expected a node object"},
+		{`{"children": []}`, "$.type", "behaviortree: $.type: missing node type"},
+		{`{"type": 3}`, "$.type", ""},
+		{`{"type": "Sequence", "name": []}`, "$.name", ""},
+		{`{"type": "Sequence", "childs": []}`, "$.childs", "behaviortree: $.childs: unknown field"},
+		{`{"type": "Sequence", "children": {}}`, "$.children", "behaviortree: $.children: expected an array of nodes"},
+		{`{"type": "Sequence", "children": [{"type": "A"}, {"kind": "B"}]}`, "$.children[1].kind", ""},
+		{`{"type": "Parallel", "params": [1]}`, "$.params", ""},
+	}
+	for _, c := range cases {
+		_, err := ParseJSON([]byte(c.document))
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) {
+			t.Errorf("%s: expected a LoadError, but got %v", c.document, err)
+			continue
+		}
+		if loadErr.Path != c.path {
+			t.Errorf("%s: expected path %q, but got %q", c.document, c.path, loadErr.Path)
+		}
+		if c.message != "" && err.Error() != c.message {
+			t.Errorf("%s: expected message %q, but got %q", c.document, c.message, err.Error())
+		}
+	}
+}
+
+func TestRegistry_LoadJSON(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree, err := registry.LoadJSON([]byte(`{
+		"type": "Priority",
+		"children": [
+			{"type": "Invert", "children": [{"type": "Succeed"}]},
+			{"type": "Parallel", "params": {"success": 1}, "children": [{"type": "Run"}, {"type": "Succeed"}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+}
+
+func TestRegistry_LoadJSONErrors(t *testing.T) {
+	registry := newTestRegistry()
+
+	if _, err := registry.LoadJSON([]byte(`not json`)); err == nil {
+		t.Error("Expected an error for an invalid document")
+	}
+
+	_, err := registry.LoadJSON([]byte(`{"type": "Sequence", "children": [{"type": "Jump"}]}`))
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.Path != "$.children[0].type" {
+		t.Errorf("Expected an unknown type error at $.children[0].type, but got %v", err)
+	}
+}
@@ -29,6 +29,8 @@ type Node[T any] interface {
 type BaseNode[T any] struct {
 	ControlNode Node[T] // The parent or controlling node managing this node.
 	Object      T       // The object passed during the node's execution.
+	Identity            // The optional name and the path ID of the node.
+	Origin              // The definition a Registry built the node from, if any.
 }
 
 // SetControl sets the control node for the current node.
@@ -73,4 +75,16 @@ func (n *BaseNode[T]) Fail() {
 	if n.ControlNode != nil {
 		n.ControlNode.Fail()
 	}
-}
\ No newline at end of file
+}
+
+// Report signals the given status to the control node. It lets a node computing a Status
+// report it through the callback-based API. Invalid is not signalled.
+func (n *BaseNode[T]) Report(status Status) {
+	report(n.ControlNode, status)
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+// Tasks use it to reach the blackboard of the tree they run in.
+func (n *BaseNode[T]) Blackboard() *Blackboard {
+	return blackboardOf(n.ControlNode)
+}
@@ -0,0 +1,168 @@
+package behaviortree
+
+// Thresholds understood by the success and failure policies of a Parallel node.
+const (
+	// RequireAll requires every child node to reach the outcome.
+	RequireAll = 0
+	// RequireOne requires a single child node to reach the outcome.
+	RequireOne = 1
+)
+
+// Parallel represents a composite node in the behavior tree that runs all of its child nodes on every tick.
+// It succeeds once SuccessThreshold children have succeeded and fails once FailureThreshold children have
+// failed, or once success can no longer be reached. Children that are still running when the Parallel node
+// resolves are halted so that they start over the next time the node runs.
+type Parallel[T any] struct {
+	ControlNode      Node[T]   // The control node managing this Parallel node.
+	Nodes            []Node[T] // The list of child nodes to execute in parallel.
+	SuccessThreshold int       // Number of children that must succeed, or RequireAll.
+	FailureThreshold int       // Number of children that must fail, or RequireAll.
+	NodeRunning      bool      // Indicates whether the children are currently running.
+	Object           T         // The object shared across nodes during execution.
+	Identity                   // The optional name and the path ID of the node.
+	Origin                     // The definition a Registry built the node from, if any.
+
+	statuses []statusRecorder[T] // Control nodes recording the last status of each child.
+}
+
+// NewParallel creates a new Parallel node with the specified child nodes and policy thresholds.
+// A threshold of RequireAll, or one larger than the number of children, requires every child.
+func NewParallel[T any](nodes []Node[T], successThreshold, failureThreshold int) *Parallel[T] {
+	parallel := &Parallel[T]{
+		Nodes:            nodes,
+		SuccessThreshold: successThreshold,
+		FailureThreshold: failureThreshold,
+	}
+	parallel.reset()
+	return parallel
+}
+
+// SetControl sets the control node for the Parallel node.
+func (p *Parallel[T]) SetControl(control Node[T]) {
+	p.ControlNode = control
+}
+
+// Start initializes the Parallel node with the provided object. The recorded child statuses are
+// only reset when the children are not already running.
+func (p *Parallel[T]) Start(object T) {
+	if !p.NodeRunning {
+		p.Object = object
+		p.reset()
+	}
+}
+
+// Run executes every child node that has not completed yet and resolves the outcome according to
+// the success and failure thresholds.
+func (p *Parallel[T]) Run(object T) {
+	if len(p.statuses) != len(p.Nodes) {
+		p.reset()
+	}
+
+	successes, failures := 0, 0
+	for i, node := range p.Nodes {
+		recorder := &p.statuses[i]
+		if recorder.Status != Success && recorder.Status != Failure {
+			if recorder.Status == Invalid {
+				node.Start(object)
+			}
+			node.SetControl(recorder)
+			node.Run(object)
+			if recorder.Status == Success || recorder.Status == Failure {
+				node.Finish(object)
+			}
+		}
+		switch recorder.Status {
+		case Success:
+			successes++
+		case Failure:
+			failures++
+		}
+	}
+
+	successThreshold := p.threshold(p.SuccessThreshold)
+	switch {
+	case successes >= successThreshold:
+		p.Halt(object)
+		p.Success()
+	case failures >= p.threshold(p.FailureThreshold) || failures > len(p.Nodes)-successThreshold:
+		p.Halt(object)
+		p.Fail()
+	default:
+		p.Running()
+	}
+}
+
+// threshold resolves a policy threshold against the number of child nodes.
+func (p *Parallel[T]) threshold(n int) int {
+	if n <= RequireAll || n > len(p.Nodes) {
+		return len(p.Nodes)
+	}
+	return n
+}
+
+// Halt interrupts the children that are still running and resets the recorded statuses.
+func (p *Parallel[T]) Halt(object T) {
+	for i, node := range p.Nodes {
+		if i < len(p.statuses) && p.statuses[i].Status == Running {
+			Halt(node, object)
+		}
+	}
+	p.reset()
+}
+
+// reset clears the recorded child statuses.
+func (p *Parallel[T]) reset() {
+	if len(p.statuses) != len(p.Nodes) {
+		p.statuses = make([]statusRecorder[T], len(p.Nodes))
+	}
+	for i := range p.statuses {
+		p.statuses[i] = statusRecorder[T]{Parent: p}
+	}
+	p.NodeRunning = false
+}
+
+// Success signals success to the control node.
+func (p *Parallel[T]) Success() {
+	if p.ControlNode != nil {
+		p.ControlNode.Success()
+	}
+}
+
+// Fail signals failure to the control node.
+func (p *Parallel[T]) Fail() {
+	if p.ControlNode != nil {
+		p.ControlNode.Fail()
+	}
+}
+
+// Running signals that the Parallel node is still in progress to the control node.
+func (p *Parallel[T]) Running() {
+	p.NodeRunning = true
+	if p.ControlNode != nil {
+		p.ControlNode.Running()
+	}
+}
+
+// Finish is a placeholder method for when the Parallel node finishes execution.
+func (p *Parallel[T]) Finish(object T) {
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (p *Parallel[T]) Blackboard() *Blackboard {
+	return blackboardOf(p.ControlNode)
+}
+
+// Children returns the child nodes of the Parallel node.
+func (p *Parallel[T]) Children() []Node[T] {
+	return p.Nodes
+}
+
+// Kind reports that the Parallel node is a composite node.
+func (p *Parallel[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (p *Parallel[T]) replaceChild(i int, node Node[T]) {
+	p.Nodes[i] = node
+}
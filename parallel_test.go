@@ -0,0 +1,156 @@
+package behaviortree
+
+import "testing"
+
+// statusTask returns a task that reports the statuses in order, repeating the last one.
+func statusTask(statuses ...Status) *Task[int] {
+	count := 0
+	return NewTask(func(task *Task[int], obj int) {
+		status := statuses[len(statuses)-1]
+		if count < len(statuses) {
+			status = statuses[count]
+		}
+		count++
+		task.Report(status)
+	})
+}
+
+func TestParallel_RequireAllSuccess(t *testing.T) {
+	task1 := statusTask(Success)
+	task2 := statusTask(Success)
+	parallel := NewParallel[int]([]Node[int]{task1, task2}, RequireAll, RequireOne)
+
+	if status := TickNode[int](parallel, 0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if !task1.RunCalled || !task2.RunCalled {
+		t.Error("Expected every child to be run in the same tick")
+	}
+}
+
+func TestParallel_RequireOneSuccess(t *testing.T) {
+	running := NewMockNode[int](t)
+	running.CustomRun = func(m *MockNode[int], obj int) { m.Control.Running() }
+	parallel := NewParallel[int]([]Node[int]{running, statusTask(Success)}, RequireOne, RequireAll)
+
+	if status := TickNode[int](parallel, 0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if !running.FinishCalled {
+		t.Error("Expected the running child to be halted once the node resolved")
+	}
+	if parallel.NodeRunning {
+		t.Error("Expected NodeRunning to be false after resolving")
+	}
+}
+
+func TestParallel_FailureThreshold(t *testing.T) {
+	parallel := NewParallel[int]([]Node[int]{
+		statusTask(Failure),
+		statusTask(Running),
+		statusTask(Running),
+	}, RequireOne, RequireOne)
+
+	if status := TickNode[int](parallel, 0); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+}
+
+func TestParallel_FailsWhenSuccessIsUnreachable(t *testing.T) {
+	parallel := NewParallel[int]([]Node[int]{
+		statusTask(Failure),
+		statusTask(Running),
+		statusTask(Running),
+	}, RequireAll, RequireAll)
+
+	if status := TickNode[int](parallel, 0); status != Failure {
+		t.Errorf("Expected Failure once success is unreachable, but got %v", status)
+	}
+}
+
+func TestParallel_ThresholdOfTwo(t *testing.T) {
+	first := statusTask(Success)
+	second := statusTask(Running, Success)
+	third := statusTask(Running)
+	parallel := NewParallel[int]([]Node[int]{first, second, third}, 2, RequireAll)
+	tree := NewBehaviorTree[int](parallel)
+
+	if status := tree.Tick(0); status != Running {
+		t.Errorf("Expected Running on first tick, but got %v", status)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success on second tick, but got %v", status)
+	}
+}
+
+func TestParallel_CompletedChildrenAreNotRerun(t *testing.T) {
+	runs := 0
+	done := NewTask(func(task *Task[int], obj int) {
+		runs++
+		task.Success()
+	})
+	parallel := NewParallel[int]([]Node[int]{done, statusTask(Running, Running, Success)}, RequireAll, RequireOne)
+	tree := NewBehaviorTree[int](parallel)
+
+	for i := 0; i < 2; i++ {
+		if status := tree.Tick(0); status != Running {
+			t.Errorf("Expected Running on tick %d, but got %v", i+1, status)
+		}
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success on last tick, but got %v", status)
+	}
+	if runs != 1 {
+		t.Errorf("Expected completed child to run once, but ran %d times", runs)
+	}
+}
+
+func TestParallel_StartDoesNotResetRunningChildren(t *testing.T) {
+	child := NewMockNode[int](t)
+	child.CustomRun = func(m *MockNode[int], obj int) { m.Control.Running() }
+	parallel := NewParallel[int]([]Node[int]{child}, RequireAll, RequireAll)
+
+	parallel.Start(1)
+	parallel.Run(1)
+	child.StartCalled = false
+	parallel.Start(2)
+	parallel.Run(2)
+
+	if child.StartCalled {
+		t.Error("Expected running child to not be started again")
+	}
+	if parallel.Object != 1 {
+		t.Errorf("Expected object to remain 1, but got %d", parallel.Object)
+	}
+}
+
+func TestParallel_NoChildren(t *testing.T) {
+	parallel := NewParallel[int](nil, RequireAll, RequireAll)
+
+	if status := TickNode[int](parallel, 0); status != Success {
+		t.Errorf("Expected Parallel without children to succeed, but got %v", status)
+	}
+}
+
+func TestParallel_NodesChangedAfterConstruction(t *testing.T) {
+	parallel := NewParallel[int](nil, RequireAll, RequireOne)
+	parallel.Nodes = []Node[int]{statusTask(Success), statusTask(Success)}
+	control := NewMockNode[int](t)
+	parallel.SetControl(control)
+
+	parallel.Run(0)
+
+	if !control.SuccessCalled {
+		t.Error("Expected Parallel to call Success on control")
+	}
+}
+
+func TestParallel_WithoutControlNode(t *testing.T) {
+	parallel := NewParallel[int]([]Node[int]{statusTask(Running, Success, Failure)}, RequireAll, RequireAll)
+
+	for i := 0; i < 3; i++ {
+		parallel.Start(0)
+		parallel.Run(0)
+	}
+	parallel.Finish(0)
+}
@@ -0,0 +1,101 @@
+package behaviortree
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Pool ticks many independent behavior trees in parallel on a bounded number of goroutines, such as the
+// instances of the agents of a simulation created with NewInstance. The trees must not share execution
+// state: each tree is ticked by a single goroutine, so the nodes of a tree need no locking, but state
+// shared between trees, such as a listener or the object, must be safe for concurrent use. Instances get
+// random sources of their own, but a *rand.Rand given to several trees with SetRand is shared.
+// A Pool is safe for concurrent use, but a tree must not be ticked by two calls at the same time.
+type Pool[T any] struct {
+	Workers int // The maximum number of goroutines ticking trees, or zero for runtime.GOMAXPROCS(0).
+}
+
+// NewPool creates a new Pool ticking trees on at most the given number of goroutines, or on
+// runtime.GOMAXPROCS(0) goroutines if workers is zero.
+func NewPool[T any](workers int) *Pool[T] {
+	return &Pool[T]{
+		Workers: workers,
+	}
+}
+
+// Tick ticks every tree once with the object at the same index and returns the statuses in the order of
+// the trees, regardless of the order in which the trees were ticked. It returns once every tree has been
+// ticked. A tree must not appear twice in the slice.
+//
+// A panic while ticking a tree does not affect the other trees: the status of the tree is Invalid and
+// the panic is reported in the returned error, in the order of the trees. The tree should be halted
+// before it is ticked again.
+func (p *Pool[T]) Tick(trees []*BehaviorTree[T], objects []T) ([]Status, error) {
+	if len(objects) != len(trees) {
+		return nil, fmt.Errorf("behaviortree: got %d objects for %d trees", len(objects), len(trees))
+	}
+
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(trees) {
+		workers = len(trees)
+	}
+
+	statuses := make([]Status, len(trees))
+	errs := make([]error, len(trees))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(trees) {
+					return
+				}
+				statuses[i], errs[i] = tickRecovered(i, trees[i], objects[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return statuses, errors.Join(errs...)
+}
+
+// tickRecovered ticks the tree at index i, reporting a panic as an error.
+func tickRecovered[T any](i int, tree *BehaviorTree[T], object T) (status Status, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			status = Invalid
+			err = fmt.Errorf("behaviortree: tree %d panicked: %v", i, r)
+		}
+	}()
+	return tree.Tick(object), nil
+}
+
+// syncListener serializes the events sent to a listener.
+type syncListener[T any] struct {
+	mu       sync.Mutex
+	listener Listener[T]
+}
+
+// NewSyncListener returns a listener passing every event to the given listener, one event at a time.
+// Listeners are called by the goroutine ticking the tree, so a listener added to several trees that
+// are ticked concurrently, for example by a Pool, receives events from several goroutines; wrapping it
+// makes a listener that is not safe for concurrent use safe to share. The events of different trees
+// are interleaved in no particular order.
+func NewSyncListener[T any](listener Listener[T]) Listener[T] {
+	return &syncListener[T]{listener: listener}
+}
+
+// OnEvent passes the event to the wrapped listener.
+func (l *syncListener[T]) OnEvent(event Event[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listener.OnEvent(event)
+}
@@ -0,0 +1,178 @@
+package behaviortree
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// poolInstances returns n instances of the template together with the objects 0 to n-1.
+func poolInstances(t *testing.T, template *BehaviorTree[int], n int) ([]*BehaviorTree[int], []int) {
+	trees := make([]*BehaviorTree[int], n)
+	objects := make([]int, n)
+	for i := range trees {
+		instance, err := template.NewInstance()
+		if err != nil {
+			t.Fatalf("Expected the template to be instantiated, but got %v", err)
+		}
+		trees[i] = instance
+		objects[i] = i
+	}
+	return trees, objects
+}
+
+func TestPool_Tick(t *testing.T) {
+	var active, peak atomic.Int32
+	template := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewTask(func(task *Task[int], obj int) {
+			current := active.Add(1)
+			for {
+				highest := peak.Load()
+				if current <= highest || peak.CompareAndSwap(highest, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			task.Success()
+		}),
+		NewCondition(func(obj int) bool { return obj%2 == 0 }),
+	}))
+	trees, objects := poolInstances(t, template, 40)
+
+	statuses, err := NewPool[int](4).Tick(trees, objects)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for i, status := range statuses {
+		if expected := []Status{Success, Failure}[i%2]; status != expected {
+			t.Errorf("Expected tree %d to report %v, but got %v", i, expected, status)
+		}
+	}
+	if peak.Load() > 4 {
+		t.Errorf("Expected at most 4 trees to be ticked at a time, but got %d", peak.Load())
+	}
+}
+
+func TestPool_DefaultWorkers(t *testing.T) {
+	template := NewBehaviorTree[int](NewCondition(func(obj int) bool { return obj%2 == 0 }))
+	trees, objects := poolInstances(t, template, 3)
+
+	statuses, err := NewPool[int](0).Tick(trees, objects)
+	if err != nil || len(statuses) != 3 || statuses[1] != Failure {
+		t.Errorf("Expected the trees to be ticked, but got %v, %v", statuses, err)
+	}
+	if statuses, err := NewPool[int](0).Tick(nil, nil); err != nil || len(statuses) != 0 {
+		t.Errorf("Expected no statuses for no trees, but got %v, %v", statuses, err)
+	}
+}
+
+func TestPool_Panic(t *testing.T) {
+	template := NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) {
+		if obj == 2 {
+			panic("boom")
+		}
+		task.Success()
+	}))
+	trees, objects := poolInstances(t, template, 4)
+
+	statuses, err := NewPool[int](2).Tick(trees, objects)
+	if err == nil || !strings.Contains(err.Error(), "tree 2 panicked: boom") {
+		t.Errorf("Expected the panic to be reported, but got %v", err)
+	}
+	for i, status := range statuses {
+		expected := Success
+		if i == 2 {
+			expected = Invalid
+		}
+		if status != expected {
+			t.Errorf("Expected tree %d to report %v, but got %v", i, expected, status)
+		}
+	}
+}
+
+func TestPool_ObjectCount(t *testing.T) {
+	trees := []*BehaviorTree[int]{NewBehaviorTree[int](statusTask(Success))}
+	if _, err := NewPool[int](1).Tick(trees, nil); err == nil {
+		t.Error("Expected an error for a missing object")
+	}
+}
+
+func TestPool_RandomizedInstances(t *testing.T) {
+	// The trees sleep before drawing so that the workers draw at the same time.
+	template := func() *BehaviorTree[int] {
+		return NewBehaviorTree[int](NewSequence([]Node[int]{
+			NewCondition(func(obj int) bool {
+				time.Sleep(100 * time.Microsecond)
+				return true
+			}),
+			randomizedTree(42),
+		}))
+	}
+	trees, objects := poolInstances(t, template(), 32)
+	replay, _ := poolInstances(t, template(), 32)
+
+	pool := NewPool[int](8)
+	for tick := 0; tick < 50; tick++ {
+		if _, err := pool.Tick(trees, objects); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if _, err := pool.Tick(replay, objects); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		for i := range trees {
+			_, orders := randomSources(trees[i])
+			_, replayed := randomSources(replay[i])
+			if !reflect.DeepEqual(orders, replayed) {
+				t.Fatalf("Expected instance %d to replay the choices of its seed on tick %d, but got %v and %v", i, tick, orders, replayed)
+			}
+		}
+	}
+}
+
+func TestPool_SharedState(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	timeout := NewTimeoutDecorator[int](runningTask(), time.Second)
+	timeout.Clock = clock
+	template := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewCondition(func(obj int) bool { return obj >= 0 }),
+		timeout,
+	}))
+	trees, objects := poolInstances(t, template, 20)
+	for i := range objects {
+		objects[i]++
+	}
+
+	buffer := NewRingBuffer[int](10000)
+	events := 0
+	counter := NewSyncListener[int](ListenerFunc[int](func(event Event[int]) {
+		events++
+	}))
+	for _, tree := range trees {
+		tree.AddListener(buffer)
+		tree.AddListener(counter)
+	}
+
+	pool := NewPool[int](8)
+	for tick := 0; tick < 3; tick++ {
+		clock.Advance(600 * time.Millisecond)
+		statuses, err := pool.Tick(trees, objects)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		expected := Running
+		if tick == 2 {
+			expected = Failure
+		}
+		for i, status := range statuses {
+			if status != expected {
+				t.Errorf("Expected tree %d to report %v on tick %d, but got %v", i, expected, tick, status)
+			}
+		}
+	}
+	if len(buffer.Events()) != events || events == 0 {
+		t.Errorf("Expected the shared listeners to receive the same %d events, but got %d", events, len(buffer.Events()))
+	}
+}
@@ -2,11 +2,15 @@ package behaviortree
 
 // Priority represents a composite node in the behavior tree that attempts to execute its child nodes in priority order.
 // If a child node fails, the Priority node moves to the next child. If a child succeeds, the Priority node succeeds.
+// When a child reports Running, the Priority node resumes at that child on the next tick.
 type Priority[T any] struct {
 	ControlNode Node[T]   // The control node managing this Priority node.
 	Nodes       []Node[T] // The list of child nodes to execute in priority order.
 	ActualTask  int       // The index of the currently executing child node.
+	NodeRunning bool      // Indicates whether the current child node is running.
 	Object      T         // The object shared across nodes during execution.
+	Identity              // The optional name and the path ID of the node.
+	Origin                // The definition a Registry built the node from, if any.
 }
 
 // NewPriority creates a new Priority node with the specified child nodes.
@@ -21,24 +25,47 @@ func (p *Priority[T]) SetControl(control Node[T]) {
 	p.ControlNode = control
 }
 
-// Start initializes the Priority node with the provided object.
+// Start initializes the Priority node with the provided object. A running Priority node is left as is,
+// so that it resumes at the running child.
 func (p *Priority[T]) Start(object T) {
-	p.Object = object
-	p.ActualTask = 0
+	if !p.NodeRunning {
+		p.Object = object
+		p.ActualTask = 0
+	}
 }
 
 // Run executes the currently active child node. If a child node fails, the Priority node moves to the next child.
+// While a child is running, a higher-priority child implementing Aborter can halt it and take over.
 func (p *Priority[T]) Run(object T) {
+	if p.NodeRunning && p.ActualTask < len(p.Nodes) {
+		p.abortLowerPriority(object)
+	}
 	if p.ActualTask < len(p.Nodes) {
 		currentNode := p.Nodes[p.ActualTask]
 		currentNode.SetControl(p)
-		currentNode.Start(object)
+		if !p.NodeRunning {
+			currentNode.Start(object)
+		}
 		currentNode.Run(object)
 	}
 }
 
+// abortLowerPriority halts the running child node if a higher-priority child requests it, and makes
+// that child the current one.
+func (p *Priority[T]) abortLowerPriority(object T) {
+	for i := 0; i < p.ActualTask; i++ {
+		if aborter, ok := unwrap(p.Nodes[i]).(Aborter[T]); ok && aborter.AbortsLowerPriority(object) {
+			Halt(p.Nodes[p.ActualTask], object)
+			p.NodeRunning = false
+			p.ActualTask = i
+			return
+		}
+	}
+}
+
 // Success is called when a child node succeeds. It signals success to the control node.
 func (p *Priority[T]) Success() {
+	p.NodeRunning = false
 	if p.ControlNode != nil {
 		p.ControlNode.Success()
 	}
@@ -47,6 +74,7 @@ func (p *Priority[T]) Success() {
 // Fail is called when a child node fails. It advances to the next child node or signals failure to the control node
 // if all children have been attempted.
 func (p *Priority[T]) Fail() {
+	p.NodeRunning = false
 	p.ActualTask++
 
 	if p.ActualTask < len(p.Nodes) {
@@ -64,7 +92,37 @@ func (p *Priority[T]) Finish(object T) {
 
 // Running signals that the Priority node is still in progress to the control node.
 func (p *Priority[T]) Running() {
+	p.NodeRunning = true
 	if p.ControlNode != nil {
 		p.ControlNode.Running()
 	}
-}
\ No newline at end of file
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (p *Priority[T]) Blackboard() *Blackboard {
+	return blackboardOf(p.ControlNode)
+}
+
+// Children returns the child nodes of the Priority node.
+func (p *Priority[T]) Children() []Node[T] {
+	return p.Nodes
+}
+
+// Kind reports that the Priority node is a composite node.
+func (p *Priority[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (p *Priority[T]) replaceChild(i int, node Node[T]) {
+	p.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the Priority node.
+func (p *Priority[T]) Halt(object T) {
+	if p.NodeRunning && p.ActualTask < len(p.Nodes) {
+		Halt(p.Nodes[p.ActualTask], object)
+	}
+	p.NodeRunning = false
+	p.ActualTask = 0
+}
@@ -2,19 +2,27 @@ package behaviortree
 
 import (
 	"math/rand"
-	"time"
 )
 
+// Randomized is implemented by nodes that make random choices, such as Random, WeightedRandom and the
+// shuffling composites. BehaviorTree.SetRand configures the random source of every randomized node of
+// a tree, so that simulations and tests can be replayed from a seed.
+type Randomized interface {
+	// SetRand sets the source of the random choices of the node. If random is nil, the global source of
+	// math/rand is used.
+	SetRand(random *rand.Rand)
+}
+
 // Random represents a composite node that selects one child node at random to execute.
 // It ensures that one of its child nodes is chosen and run each time it starts.
 type Random[T any] struct {
-	BranchNode[T] // Embeds the BranchNode structure to manage child nodes.
+	BranchNode[T]            // Embeds the BranchNode structure to manage child nodes.
+	Rand          *rand.Rand // The source of the random choices, or nil to use the global source of math/rand.
 }
 
 // NewRandom creates a new Random node with the specified child nodes.
 // Each time the node starts, it selects a child node at random.
 func NewRandom[T any](nodes []Node[T]) *Random[T] {
-	rand.Seed(time.Now().UnixNano())
 	bn := NewBranchNode(nodes)
 	return &Random[T]{
 		BranchNode: *bn,
@@ -23,16 +31,31 @@ func NewRandom[T any](nodes []Node[T]) *Random[T] {
 
 // Start initializes the Random node and selects a random child node to execute.
 func (r *Random[T]) Start(object T) {
+	if r.NodeRunning {
+		return
+	}
 	r.BranchNode.Start(object)
 	if len(r.Nodes) > 0 {
-		r.ActualTask = rand.Intn(len(r.Nodes)) // Select a random child node
-		r.Nodes[r.ActualTask].Start(object)   // Start the selected child node
+		r.ActualTask = randIntn(r.Rand, len(r.Nodes)) // Select a random child node
+		r.Nodes[r.ActualTask].Start(object)           // Start the selected child node
+	}
+}
+
+// Run executes the selected child node, making the Random node its control so that the child's
+// outcome is reported through it.
+func (r *Random[T]) Run(object T) {
+	if r.ActualTask < len(r.Nodes) {
+		if !r.NodeRunning {
+			r.Node = r.Nodes[r.ActualTask]
+			r.Node.SetControl(r)
+		}
+		r.Node.Run(object)
 	}
 }
 
 // Success is called when the selected child node succeeds.
 func (r *Random[T]) Success() {
-	r.BaseNode.Success()
+	r.BranchNode.Success()
 	if r.ControlNode != nil {
 		r.ControlNode.Success()
 	}
@@ -40,8 +63,49 @@ func (r *Random[T]) Success() {
 
 // Fail is called when the selected child node fails.
 func (r *Random[T]) Fail() {
-	r.BaseNode.Fail()
+	r.BranchNode.Fail()
 	if r.ControlNode != nil {
 		r.ControlNode.Fail()
 	}
-}
\ No newline at end of file
+}
+
+// SetRand sets the source of the random choices of the Random node.
+func (r *Random[T]) SetRand(random *rand.Rand) {
+	r.Rand = random
+}
+
+// setRand sets the source of the random choices of every node of the tree rooted at node implementing
+// Randomized.
+func setRand[T any](node Node[T], random *rand.Rand) {
+	Walk[T](node, func(node Node[T], depth int) bool {
+		if randomized, ok := node.(Randomized); ok {
+			randomized.SetRand(random)
+		}
+		return true
+	})
+}
+
+// deriveRand returns a new source seeded with a number drawn from random, or nil if random is nil, so
+// that copies of a node do not share a source but replay the same choices for the same seed.
+func deriveRand(random *rand.Rand) *rand.Rand {
+	if random == nil {
+		return nil
+	}
+	return rand.New(rand.NewSource(random.Int63()))
+}
+
+// randIntn returns a random number in [0, n) drawn from random, or from the global source if random is nil.
+func randIntn(random *rand.Rand, n int) int {
+	if random == nil {
+		return rand.Intn(n)
+	}
+	return random.Intn(n)
+}
+
+// randFloat64 returns a random number in [0, 1) drawn from random, or from the global source if random is nil.
+func randFloat64(random *rand.Rand) float64 {
+	if random == nil {
+		return rand.Float64()
+	}
+	return random.Float64()
+}
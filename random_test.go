@@ -1,6 +1,8 @@
 package behaviortree
 
 import (
+	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -95,3 +97,66 @@ func TestRandom_Randomness(t *testing.T) {
 		}
 	}
 }
+
+func TestRandom_ReportsChildOutcome(t *testing.T) {
+	node := &MockNode[int]{
+		CustomRun: func(m *MockNode[int], obj int) {
+			m.Control.Fail()
+		},
+	}
+	control := &MockNode[int]{}
+
+	random := NewRandom([]Node[int]{node})
+	random.SetControl(control)
+	random.Start(42)
+	random.Run(42)
+
+	if !control.FailCalled {
+		t.Error("Expected the child's failure to be reported to the control node")
+	}
+	if control.SuccessCalled {
+		t.Error("Expected Success to not be called on the control node")
+	}
+	if !node.FinishCalled {
+		t.Error("Expected the child node to be finished")
+	}
+}
+
+// pickedChildren ticks a tree of a Random and a WeightedRandom node seeded with seed and returns the
+// indexes of the picked children.
+func pickedChildren(seed int64, ticks int) []int {
+	var picks []int
+	pick := func(i int) Node[int] {
+		return NewTask(func(task *Task[int], obj int) {
+			picks = append(picks, i)
+			task.Success()
+		})
+	}
+	inner := NewBehaviorTree[int](NewWeightedRandom([]Node[int]{pick(4), pick(5), pick(6)}, []float64{1, 2, 3}))
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewRandom([]Node[int]{pick(0), pick(1), pick(2), pick(3)}),
+		inner,
+	}))
+	tree.SetRand(rand.New(rand.NewSource(seed)))
+	for i := 0; i < ticks; i++ {
+		tree.Tick(0)
+	}
+	return picks
+}
+
+func TestBehaviorTree_SetRand(t *testing.T) {
+	first, replay := pickedChildren(42, 50), pickedChildren(42, 50)
+	if !reflect.DeepEqual(first, replay) {
+		t.Errorf("Expected the same seed to replay the same choices:\n%v\n%v", first, replay)
+	}
+	if other := pickedChildren(7, 50); reflect.DeepEqual(first, other) {
+		t.Errorf("Expected another seed to make other choices, but got %v", other)
+	}
+
+	random := NewRandom([]Node[int]{&MockNode[int]{}})
+	random.SetRand(rand.New(rand.NewSource(1)))
+	NewBehaviorTree[int](random).SetRand(nil)
+	if random.Rand != nil {
+		t.Error("Expected a nil source to restore the global source")
+	}
+}
@@ -0,0 +1,87 @@
+package behaviortree
+
+import "time"
+
+// RateLimitDecorator is a decorator node that runs its child node at most Limit times per Window. An
+// execution counts from the tick that starts the child node, and the window slides with time: once Limit
+// executions have started within the last Window, the decorator fails immediately without running the
+// child node. The time is measured with Clock. The executions are kept by the decorator, so every tree
+// built with its own decorator has its own limit.
+type RateLimitDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	Limit  int           // Maximum number of executions per window.
+	Window time.Duration // The duration of the sliding window.
+	Clock  Clock         // Measures the time. If nil, the system clock is used.
+
+	Executions  []time.Time // The start times of the executions within the current window, oldest first.
+	NodeRunning bool        // Indicates whether the child node has been started and has not completed yet.
+}
+
+// NewRateLimitDecorator creates a new RateLimitDecorator that runs the specified child node at most limit
+// times per window.
+func NewRateLimitDecorator[T any](node Node[T], limit int, window time.Duration) *RateLimitDecorator[T] {
+	decorator := &RateLimitDecorator[T]{
+		Limit:  limit,
+		Window: window,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless the child node is running.
+func (d *RateLimitDecorator[T]) Start(object T) {
+	if !d.NodeRunning {
+		d.setObject(object)
+	}
+}
+
+// Run executes the child node, starting it first if it is not running. If the limit has been reached,
+// the decorator fails without running the child node.
+func (d *RateLimitDecorator[T]) Run(object T) {
+	if !d.NodeRunning {
+		now := clockOrSystem(d.Clock).Now()
+		expired := 0
+		for expired < len(d.Executions) && !d.Executions[expired].After(now.Add(-d.Window)) {
+			expired++
+		}
+		d.Executions = append(d.Executions[:0], d.Executions[expired:]...)
+		if len(d.Executions) >= d.Limit {
+			if d.ControlNode != nil {
+				d.ControlNode.Fail()
+			}
+			return
+		}
+		d.Executions = append(d.Executions, now)
+		d.NodeRunning = true
+		d.Node.Start(object)
+	}
+	d.Node.Run(object)
+}
+
+// Success is called when the child node succeeds. It signals success to the control node.
+func (d *RateLimitDecorator[T]) Success() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails. It signals failure to the control node.
+func (d *RateLimitDecorator[T]) Fail() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// Halt interrupts the running child node. The interrupted execution still counts towards the limit.
+func (d *RateLimitDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+}
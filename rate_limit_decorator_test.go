@@ -0,0 +1,82 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDecorator(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	child := newHaltableTask(Success, Running, Failure, Success)
+	limit := NewRateLimitDecorator[int](child, 2, time.Minute)
+	limit.Clock = clock
+	tree := NewBehaviorTree[int](limit)
+
+	statuses := []Status{tree.Tick(0)}
+	clock.Advance(30 * time.Second)
+	statuses = append(statuses, tree.Tick(0), tree.Tick(0), tree.Tick(0))
+	clock.Advance(30 * time.Second)
+	statuses = append(statuses, tree.Tick(0), tree.Tick(0))
+
+	expected := []Status{Success, Running, Failure, Failure, Success, Failure}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.started != 3 || len(limit.Executions) != 2 {
+		t.Errorf("Expected 3 executions with 2 in the window, got %d and %d", child.started, len(limit.Executions))
+	}
+}
+
+func TestRateLimitDecorator_WithoutControl(t *testing.T) {
+	limit := NewRateLimitDecorator[int](statusTask(Success, Failure), 1, time.Hour)
+	limit.Start(0)
+	limit.Run(0)
+	limit.Run(0)
+	limit.Fail()
+	if limit.NodeRunning || len(limit.Executions) != 1 {
+		t.Error("Expected the limit to hold without a control node")
+	}
+}
+
+func TestRateLimitDecorator_Halt(t *testing.T) {
+	child := newHaltableTask(Running)
+	limit := NewRateLimitDecorator[int](child, 1, time.Hour)
+
+	TickNode[int](limit, 0)
+	limit.Halt(0)
+	limit.Halt(0)
+	if child.halted != 1 || limit.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+	if status := TickNode[int](limit, 0); status != Failure {
+		t.Errorf("Expected the halted execution to count towards the limit, but got %v", status)
+	}
+}
+
+func TestRegistry_RateLimit(t *testing.T) {
+	registry := newTestRegistry()
+
+	node, err := registry.Build(Definition{Type: "RateLimit", Params: Params{"limit": 5, "window": "1m"}, Children: []Definition{{Type: "Succeed"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if limit := node.(*RateLimitDecorator[int]); limit.Limit != 5 || limit.Window != time.Minute {
+		t.Errorf("Unexpected decorator %+v", limit)
+	}
+	definition, err := registry.Describe(NewRateLimitDecorator[int](node, 3, time.Second))
+	if err != nil || !reflect.DeepEqual(definition.Params, Params{"limit": 3, "window": "1s"}) {
+		t.Errorf("Expected the limit to be described, but got %+v (%v)", definition, err)
+	}
+
+	errorCases := []Definition{
+		{Type: "RateLimit"},
+		{Type: "RateLimit", Params: Params{"limit": "many"}, Children: []Definition{{Type: "Succeed"}}},
+		{Type: "RateLimit", Params: Params{"window": "long"}, Children: []Definition{{Type: "Succeed"}}},
+	}
+	for _, definition := range errorCases {
+		if _, err := registry.Build(definition); err == nil {
+			t.Errorf("Expected an error for %+v", definition)
+		}
+	}
+}
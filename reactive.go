@@ -0,0 +1,252 @@
+package behaviortree
+
+// ReactiveSequence represents a composite node that executes its child nodes in order like a Sequence,
+// but re-evaluates every child before the running one on each tick. If an earlier child fails, or
+// reports Running itself, the child that was running is halted. This lets preconditions placed first
+// interrupt a long-running action the moment they stop holding.
+type ReactiveSequence[T any] struct {
+	ControlNode Node[T]   // The control node managing this ReactiveSequence node.
+	Nodes       []Node[T] // The list of child nodes to execute in sequence.
+	ActualTask  int       // The index of the running child node.
+	NodeRunning bool      // Indicates whether a child node is running.
+	Object      T         // The object shared across nodes during execution.
+	Identity              // The optional name and the path ID of the node.
+	Origin                // The definition a Registry built the node from, if any.
+
+	recorder statusRecorder[T] // Control node recording the status of the child being ticked.
+}
+
+// NewReactiveSequence creates a new ReactiveSequence node with the specified child nodes.
+func NewReactiveSequence[T any](nodes []Node[T]) *ReactiveSequence[T] {
+	return &ReactiveSequence[T]{
+		Nodes: nodes,
+	}
+}
+
+// SetControl sets the control node for the ReactiveSequence node.
+func (s *ReactiveSequence[T]) SetControl(control Node[T]) {
+	s.ControlNode = control
+}
+
+// Start initializes the ReactiveSequence node with the provided object.
+func (s *ReactiveSequence[T]) Start(object T) {
+	if !s.NodeRunning {
+		s.Object = object
+	}
+}
+
+// Run ticks the child nodes in order until one of them fails or reports Running. The node succeeds
+// once every child has succeeded within the same tick.
+func (s *ReactiveSequence[T]) Run(object T) {
+	s.recorder.Parent = s
+	status, running := runReactive(s.Nodes, &s.recorder, s.runningIndex(), Success, object)
+	s.ActualTask, s.NodeRunning = running, running >= 0
+	if running < 0 {
+		s.ActualTask = 0
+	}
+	report[T](s, status)
+}
+
+// runningIndex returns the index of the running child node, or -1 if no child is running.
+func (s *ReactiveSequence[T]) runningIndex() int {
+	if s.NodeRunning {
+		return s.ActualTask
+	}
+	return -1
+}
+
+// Halt interrupts the running child node and resets the ReactiveSequence node.
+func (s *ReactiveSequence[T]) Halt(object T) {
+	if s.NodeRunning && s.ActualTask < len(s.Nodes) {
+		Halt(s.Nodes[s.ActualTask], object)
+	}
+	s.NodeRunning = false
+	s.ActualTask = 0
+}
+
+// Success signals success to the control node.
+func (s *ReactiveSequence[T]) Success() {
+	if s.ControlNode != nil {
+		s.ControlNode.Success()
+	}
+}
+
+// Fail signals failure to the control node.
+func (s *ReactiveSequence[T]) Fail() {
+	if s.ControlNode != nil {
+		s.ControlNode.Fail()
+	}
+}
+
+// Running signals that the ReactiveSequence node is still in progress to the control node.
+func (s *ReactiveSequence[T]) Running() {
+	if s.ControlNode != nil {
+		s.ControlNode.Running()
+	}
+}
+
+// Finish is a placeholder method for when the ReactiveSequence node finishes execution.
+func (s *ReactiveSequence[T]) Finish(object T) {
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (s *ReactiveSequence[T]) Blackboard() *Blackboard {
+	return blackboardOf(s.ControlNode)
+}
+
+// Children returns the child nodes of the ReactiveSequence node.
+func (s *ReactiveSequence[T]) Children() []Node[T] {
+	return s.Nodes
+}
+
+// Kind reports that the ReactiveSequence node is a composite node.
+func (s *ReactiveSequence[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (s *ReactiveSequence[T]) replaceChild(i int, node Node[T]) {
+	s.Nodes[i] = node
+}
+
+// ReactiveFallback represents a composite node that tries its child nodes in priority order like a
+// Priority node, but re-evaluates every child before the running one on each tick. If a higher-priority
+// child succeeds, or reports Running itself, the child that was running is halted.
+type ReactiveFallback[T any] struct {
+	ControlNode Node[T]   // The control node managing this ReactiveFallback node.
+	Nodes       []Node[T] // The list of child nodes to execute in priority order.
+	ActualTask  int       // The index of the running child node.
+	NodeRunning bool      // Indicates whether a child node is running.
+	Object      T         // The object shared across nodes during execution.
+	Identity              // The optional name and the path ID of the node.
+	Origin                // The definition a Registry built the node from, if any.
+
+	recorder statusRecorder[T] // Control node recording the status of the child being ticked.
+}
+
+// NewReactiveFallback creates a new ReactiveFallback node with the specified child nodes.
+func NewReactiveFallback[T any](nodes []Node[T]) *ReactiveFallback[T] {
+	return &ReactiveFallback[T]{
+		Nodes: nodes,
+	}
+}
+
+// SetControl sets the control node for the ReactiveFallback node.
+func (f *ReactiveFallback[T]) SetControl(control Node[T]) {
+	f.ControlNode = control
+}
+
+// Start initializes the ReactiveFallback node with the provided object.
+func (f *ReactiveFallback[T]) Start(object T) {
+	if !f.NodeRunning {
+		f.Object = object
+	}
+}
+
+// Run ticks the child nodes in order until one of them succeeds or reports Running. The node fails
+// once every child has failed within the same tick.
+func (f *ReactiveFallback[T]) Run(object T) {
+	f.recorder.Parent = f
+	status, running := runReactive(f.Nodes, &f.recorder, f.runningIndex(), Failure, object)
+	f.ActualTask, f.NodeRunning = running, running >= 0
+	if running < 0 {
+		f.ActualTask = 0
+	}
+	report[T](f, status)
+}
+
+// runningIndex returns the index of the running child node, or -1 if no child is running.
+func (f *ReactiveFallback[T]) runningIndex() int {
+	if f.NodeRunning {
+		return f.ActualTask
+	}
+	return -1
+}
+
+// Halt interrupts the running child node and resets the ReactiveFallback node.
+func (f *ReactiveFallback[T]) Halt(object T) {
+	if f.NodeRunning && f.ActualTask < len(f.Nodes) {
+		Halt(f.Nodes[f.ActualTask], object)
+	}
+	f.NodeRunning = false
+	f.ActualTask = 0
+}
+
+// Success signals success to the control node.
+func (f *ReactiveFallback[T]) Success() {
+	if f.ControlNode != nil {
+		f.ControlNode.Success()
+	}
+}
+
+// Fail signals failure to the control node.
+func (f *ReactiveFallback[T]) Fail() {
+	if f.ControlNode != nil {
+		f.ControlNode.Fail()
+	}
+}
+
+// Running signals that the ReactiveFallback node is still in progress to the control node.
+func (f *ReactiveFallback[T]) Running() {
+	if f.ControlNode != nil {
+		f.ControlNode.Running()
+	}
+}
+
+// Finish is a placeholder method for when the ReactiveFallback node finishes execution.
+func (f *ReactiveFallback[T]) Finish(object T) {
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (f *ReactiveFallback[T]) Blackboard() *Blackboard {
+	return blackboardOf(f.ControlNode)
+}
+
+// Children returns the child nodes of the ReactiveFallback node.
+func (f *ReactiveFallback[T]) Children() []Node[T] {
+	return f.Nodes
+}
+
+// Kind reports that the ReactiveFallback node is a composite node.
+func (f *ReactiveFallback[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (f *ReactiveFallback[T]) replaceChild(i int, node Node[T]) {
+	f.Nodes[i] = node
+}
+
+// runReactive ticks the nodes in order as long as they report pass, and returns the status of the
+// first node reporting anything else, or pass if every node does. Running is the index of the node left
+// running by the previous tick, or -1; it is halted if the tick stops at an earlier node. The index of
+// the node left running by this tick is returned, or -1 if none is.
+func runReactive[T any](nodes []Node[T], recorder *statusRecorder[T], running int, pass Status, object T) (Status, int) {
+	for i, node := range nodes {
+		recorder.Status = Invalid
+		node.SetControl(recorder)
+		if i != running {
+			node.Start(object)
+		}
+		node.Run(object)
+
+		status := recorder.Status
+		if status == Success || status == Failure {
+			node.Finish(object)
+			if i == running {
+				running = -1
+			}
+			if status == pass {
+				continue
+			}
+		}
+		if running > i {
+			Halt(nodes[running], object)
+		}
+		if status == Success || status == Failure {
+			return status, -1
+		}
+		return Running, i
+	}
+	return pass, -1
+}
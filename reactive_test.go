@@ -0,0 +1,144 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReactiveSequence_ReevaluatesConditions(t *testing.T) {
+	condition := newHaltableTask(Success, Success, Failure)
+	action := newHaltableTask(Running)
+	tree := NewBehaviorTree[int](NewReactiveSequence([]Node[int]{condition, action}))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0), tree.Tick(0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Running, Failure}) {
+		t.Errorf("Expected Running, Running, Failure, but got %v", statuses)
+	}
+	if condition.runs != 3 || action.runs != 2 {
+		t.Errorf("Expected the condition to be checked every tick, got %d condition and %d action runs", condition.runs, action.runs)
+	}
+	if action.started != 1 || action.halted != 1 {
+		t.Errorf("Expected the running action to be started once and halted once, got %d and %d", action.started, action.halted)
+	}
+}
+
+func TestReactiveSequence_Success(t *testing.T) {
+	sequence := NewReactiveSequence([]Node[int]{statusTask(Success), statusTask(Running, Success)})
+
+	statuses := []Status{TickNode[int](sequence, 0), TickNode[int](sequence, 0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+		t.Errorf("Expected Running then Success, but got %v", statuses)
+	}
+	if sequence.NodeRunning || sequence.ActualTask != 0 {
+		t.Error("Expected the sequence to be reset after success")
+	}
+	if status := TickNode[int](NewReactiveSequence[int](nil), 0); status != Success {
+		t.Errorf("Expected an empty sequence to succeed, but got %v", status)
+	}
+}
+
+func TestReactiveSequence_EarlierChildRunning(t *testing.T) {
+	condition := newHaltableTask(Success, Running)
+	action := newHaltableTask(Running)
+	sequence := NewReactiveSequence([]Node[int]{condition, action})
+
+	TickNode[int](sequence, 0)
+	if status := TickNode[int](sequence, 0); status != Running {
+		t.Errorf("Expected Running, but got %v", status)
+	}
+	if action.halted != 1 || sequence.ActualTask != 0 {
+		t.Errorf("Expected the later child to be halted in favour of the earlier one, got %d halts at %d", action.halted, sequence.ActualTask)
+	}
+
+	sequence.Halt(0)
+	if condition.halted != 1 || sequence.NodeRunning {
+		t.Error("Expected Halt to interrupt the running child")
+	}
+	sequence.Halt(0)
+	if condition.halted != 1 {
+		t.Error("Expected halting an idle sequence to have no effect")
+	}
+}
+
+func TestReactiveFallback_HigherPriorityInterrupts(t *testing.T) {
+	emergency := newHaltableTask(Failure, Failure, Success)
+	patrol := newHaltableTask(Running)
+	tree := NewBehaviorTree[int](NewReactiveFallback([]Node[int]{emergency, patrol}))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0), tree.Tick(0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Running, Success}) {
+		t.Errorf("Expected Running, Running, Success, but got %v", statuses)
+	}
+	if patrol.started != 1 || patrol.halted != 1 {
+		t.Errorf("Expected the running child to be started once and halted once, got %d and %d", patrol.started, patrol.halted)
+	}
+}
+
+func TestReactiveFallback_Failure(t *testing.T) {
+	fallback := NewReactiveFallback([]Node[int]{statusTask(Failure), statusTask(Running, Failure)})
+
+	statuses := []Status{TickNode[int](fallback, 0), TickNode[int](fallback, 0)}
+	if !reflect.DeepEqual(statuses, []Status{Running, Failure}) {
+		t.Errorf("Expected Running then Failure, but got %v", statuses)
+	}
+
+	running := newHaltableTask(Running)
+	fallback = NewReactiveFallback([]Node[int]{statusTask(Failure), running})
+	TickNode[int](fallback, 0)
+	fallback.Halt(0)
+	fallback.Halt(0)
+	if running.halted != 1 || fallback.NodeRunning {
+		t.Errorf("Expected Halt to interrupt the running child once, but got %d", running.halted)
+	}
+}
+
+func TestReactive_Blackboard(t *testing.T) {
+	blackboard := NewBlackboard()
+	var seen []*Blackboard
+	task := NewTask(func(task *Task[int], obj int) {
+		seen = append(seen, task.Blackboard())
+		task.Success()
+	})
+	for _, node := range []Node[int]{NewReactiveSequence([]Node[int]{task}), NewReactiveFallback([]Node[int]{task})} {
+		tree := NewBehaviorTree(node)
+		tree.SetBlackboard(blackboard)
+		tree.Tick(0)
+	}
+	if len(seen) != 2 || seen[0] != blackboard || seen[1] != blackboard {
+		t.Errorf("Expected the children to see the tree's blackboard, but got %v", seen)
+	}
+}
+
+func TestReactive_Structure(t *testing.T) {
+	registry := newTestRegistry()
+	definition := Definition{Type: "ReactiveSequence", Children: []Definition{
+		{Type: "ReactiveFallback", Children: []Definition{{Type: "Succeed"}}},
+	}}
+	root, err := registry.Build(definition)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if KindOf(root) != KindComposite || KindOf(ChildrenOf(root)[0]) != KindComposite {
+		t.Error("Expected reactive nodes to be composites")
+	}
+
+	tree := NewBehaviorTree(root)
+	buffer := NewRingBuffer[int](100)
+	tree.AddListener(buffer)
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if len(buffer.Events()) != 12 {
+		t.Errorf("Expected the children of reactive nodes to be traced, but got %v", eventNames(buffer.Events()))
+	}
+
+	described, err := registry.Describe(tree)
+	if err != nil || !reflect.DeepEqual(described, definition) {
+		t.Errorf("Expected the tree to be described as %+v, but got %+v (%v)", definition, described, err)
+	}
+	for _, node := range []Node[int]{NewReactiveSequence[int](nil), NewReactiveFallback[int](nil)} {
+		if _, ok := describeBuiltin(node); !ok {
+			t.Errorf("Expected %T to be a built-in node", node)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+package behaviortree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrChildCount is returned by node factories when a definition has the wrong number of children.
+var ErrChildCount = errors.New("wrong number of children")
+
+// Factory builds a node from the parameters and the already built children of a definition.
+type Factory[T any] func(params Params, children []Node[T]) (Node[T], error)
+
+// LoadError reports a definition that could not be loaded. Path points at the offending part of the
+// document, using JSON path syntax for definitions and JSON documents, such as "$.children[1].params.count",
+// and XPath syntax for XML documents.
+type LoadError struct {
+	Path string // The location of the error in the document.
+	Err  error  // The underlying error.
+}
+
+// Error returns the description of the load error.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("behaviortree: %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Registry maps node type names to the factories that build them. It is used to build trees from
+// definitions, such as those loaded from JSON documents. A new registry knows the built-in node types:
+// Sequence, Priority, Random, WeightedRandom (param "weights"), ShuffleSelector, ShuffleSequence,
+// ReactiveSequence, ReactiveFallback, Parallel (params "success" and "failure"), Invert, AlwaysSucceed,
+// AlwaysFail, UntilFail, Scope, Repeat (params "count" and "ignore_failure"), Retry (params "attempts"
+// and "delay", a fixed backoff), Timeout (param "timeout"), Cooldown (param "cooldown") and RateLimit
+// (params "limit" and "window"). RegisterLibrary adds the SubTree type.
+//
+// Nodes embedding Origin, as BaseNode, BranchNode and Decorator do, keep the type and parameters the
+// registry built them from, so that trees built from definitions can be described and exported again.
+// The registry itself keeps no reference to the nodes it builds.
+type Registry[T any] struct {
+	factories map[string]Factory[T] // The registered factories by type name.
+}
+
+// NewRegistry creates a new Registry with the built-in node types registered.
+func NewRegistry[T any]() *Registry[T] {
+	r := &Registry[T]{
+		factories: make(map[string]Factory[T]),
+	}
+	r.RegisterComposite("Sequence", func(nodes []Node[T]) Node[T] { return NewSequence(nodes) })
+	r.RegisterComposite("Priority", func(nodes []Node[T]) Node[T] { return NewPriority(nodes) })
+	r.RegisterComposite("Random", func(nodes []Node[T]) Node[T] { return NewRandom(nodes) })
+	r.Register("WeightedRandom", func(params Params, children []Node[T]) (Node[T], error) {
+		weights, err := params.Floats("weights", nil)
+		if err != nil {
+			return nil, err
+		}
+		return NewWeightedRandom(children, weights), nil
+	})
+	r.RegisterComposite("ShuffleSelector", func(nodes []Node[T]) Node[T] { return NewShuffleSelector(nodes) })
+	r.RegisterComposite("ShuffleSequence", func(nodes []Node[T]) Node[T] { return NewShuffleSequence(nodes) })
+	r.RegisterComposite("ReactiveSequence", func(nodes []Node[T]) Node[T] { return NewReactiveSequence(nodes) })
+	r.RegisterComposite("ReactiveFallback", func(nodes []Node[T]) Node[T] { return NewReactiveFallback(nodes) })
+	r.Register("Parallel", func(params Params, children []Node[T]) (Node[T], error) {
+		success, err := params.Int("success", RequireAll)
+		if err != nil {
+			return nil, err
+		}
+		failure, err := params.Int("failure", RequireOne)
+		if err != nil {
+			return nil, err
+		}
+		return NewParallel(children, success, failure), nil
+	})
+	r.RegisterDecorator("Invert", func(node Node[T]) Node[T] { return NewInvertDecorator(node) })
+	r.RegisterDecorator("AlwaysSucceed", func(node Node[T]) Node[T] { return NewAlwaysSucceedDecorator(node) })
+	r.RegisterDecorator("AlwaysFail", func(node Node[T]) Node[T] { return NewAlwaysFailDecorator(node) })
+	r.RegisterDecorator("UntilFail", func(node Node[T]) Node[T] { return NewUntilFailDecorator(node) })
+	r.RegisterDecorator("Scope", func(node Node[T]) Node[T] { return NewScopeDecorator(node) })
+	r.Register("Repeat", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		count, err := params.Int("count", RepeatForever)
+		if err != nil {
+			return nil, err
+		}
+		ignoreFailure, err := params.Bool("ignore_failure", false)
+		if err != nil {
+			return nil, err
+		}
+		return NewRepeatDecorator(children[0], count, ignoreFailure), nil
+	})
+	r.Register("Retry", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		attempts, err := params.Int("attempts", RetryForever)
+		if err != nil {
+			return nil, err
+		}
+		delay, err := params.Duration("delay", 0)
+		if err != nil {
+			return nil, err
+		}
+		var backoff Backoff
+		if delay > 0 {
+			backoff = FixedBackoff(delay)
+		}
+		return NewRetryDecorator(children[0], attempts, backoff), nil
+	})
+	r.Register("Timeout", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		timeout, err := params.Duration("timeout", 0)
+		if err != nil {
+			return nil, err
+		}
+		return NewTimeoutDecorator(children[0], timeout), nil
+	})
+	r.Register("Cooldown", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		cooldown, err := params.Duration("cooldown", 0)
+		if err != nil {
+			return nil, err
+		}
+		return NewCooldownDecorator(children[0], cooldown), nil
+	})
+	r.Register("RateLimit", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		limit, err := params.Int("limit", 1)
+		if err != nil {
+			return nil, err
+		}
+		window, err := params.Duration("window", 0)
+		if err != nil {
+			return nil, err
+		}
+		return NewRateLimitDecorator(children[0], limit, window), nil
+	})
+	return r
+}
+
+// Register registers the factory under the given type name, replacing any previous registration.
+func (r *Registry[T]) Register(name string, factory Factory[T]) {
+	r.factories[name] = factory
+}
+
+// RegisterTask registers a leaf node type that builds a Task running the given function.
+func (r *Registry[T]) RegisterTask(name string, run func(task *Task[T], object T)) {
+	r.Register(name, func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 0); err != nil {
+			return nil, err
+		}
+		return NewTask(run), nil
+	})
+}
+
+// RegisterCondition registers a leaf node type that builds a Condition checking the given function.
+func (r *Registry[T]) RegisterCondition(name string, check func(object T) bool) {
+	r.Register(name, func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 0); err != nil {
+			return nil, err
+		}
+		return NewCondition(check), nil
+	})
+}
+
+// RegisterAsyncTask registers a leaf node type that builds an AsyncTask running the given function
+// in a goroutine.
+func (r *Registry[T]) RegisterAsyncTask(name string, run func(ctx context.Context, object T) error) {
+	r.Register(name, func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 0); err != nil {
+			return nil, err
+		}
+		return NewAsyncTask(run), nil
+	})
+}
+
+// RegisterDecorator registers a decorator node type that wraps exactly one child.
+func (r *Registry[T]) RegisterDecorator(name string, decorate func(node Node[T]) Node[T]) {
+	r.Register(name, func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 1); err != nil {
+			return nil, err
+		}
+		return decorate(children[0]), nil
+	})
+}
+
+// RegisterComposite registers a composite node type that accepts any number of children.
+func (r *Registry[T]) RegisterComposite(name string, compose func(nodes []Node[T]) Node[T]) {
+	r.Register(name, func(params Params, children []Node[T]) (Node[T], error) {
+		return compose(children), nil
+	})
+}
+
+// Build builds the node described by the definition and all of its descendants.
+func (r *Registry[T]) Build(definition Definition) (Node[T], error) {
+	return r.build(definition, "$")
+}
+
+// build builds the node described by the definition located at path.
+func (r *Registry[T]) build(definition Definition, path string) (Node[T], error) {
+	factory, ok := r.factories[definition.Type]
+	if !ok {
+		return nil, &LoadError{Path: path + ".type", Err: fmt.Errorf("unknown node type %q", definition.Type)}
+	}
+
+	children := make([]Node[T], 0, len(definition.Children))
+	for i, child := range definition.Children {
+		node, err := r.build(child, path+".children["+strconv.Itoa(i)+"]")
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+
+	node, err := factory(definition.Params, children)
+	if err != nil {
+		var paramErr *ParamError
+		switch {
+		case errors.As(err, &paramErr):
+			path += ".params." + paramErr.Name
+		case errors.Is(err, ErrChildCount):
+			path += ".children"
+		}
+		return nil, &LoadError{Path: path, Err: err}
+	}
+	if identifier, ok := node.(Identifier); ok && definition.Name != "" {
+		identifier.SetName(definition.Name)
+	}
+	if holder, ok := node.(definitionHolder); ok {
+		holder.setDefinition(&Definition{Type: definition.Type, Params: definition.Params})
+	}
+	return node, nil
+}
+
+// Describe returns the definition of the tree rooted at node. Built-in nodes are described by their
+// registered type and their current fields, so that changes made after loading a tree are exported;
+// other nodes built by the registry are described by the type and parameters they were built from. Names are taken from the nodes. Nested behavior trees are described by their root node,
+// and SubTree nodes by the tree they reference. A Backoff is a function and cannot be described, so
+// a Retry node created in code is described without its delay, clock or tick duration; a Retry node built
+// from a definition keeps the "delay" it was built with.
+func (r *Registry[T]) Describe(node Node[T]) (Definition, error) {
+	if tree, ok := node.(*BehaviorTree[T]); ok {
+		return r.Describe(unwrap(tree.RootNode))
+	}
+
+	var built *Definition
+	if holder, ok := node.(definitionHolder); ok {
+		built = holder.builtDefinition()
+	}
+	definition, ok := describeBuiltin(node)
+	switch {
+	case ok && built != nil && built.Type == definition.Type:
+		// The fields of the node win over the params it was built from, which only fill in what the
+		// fields cannot tell, such as the delay of a Retry node.
+		for name, value := range built.Params {
+			if _, described := definition.Params[name]; !described {
+				if definition.Params == nil {
+					definition.Params = make(Params)
+				}
+				definition.Params[name] = value
+			}
+		}
+	case built != nil:
+		definition = Definition{Type: built.Type, Params: built.Params}
+	case !ok:
+		return Definition{}, fmt.Errorf("behaviortree: cannot describe node %s of type %T: it was not built by the registry", describeNode(node), node)
+	}
+	if identifier, ok := node.(Identifier); ok {
+		definition.Name = identifier.Name()
+	}
+	if _, ok := node.(*SubTree[T]); ok {
+		return definition, nil
+	}
+
+	for _, child := range ChildrenOf(node) {
+		childDefinition, err := r.Describe(child)
+		if err != nil {
+			return Definition{}, err
+		}
+		definition.Children = append(definition.Children, childDefinition)
+	}
+	return definition, nil
+}
+
+// describeBuiltin returns the type and parameters of a built-in node.
+func describeBuiltin[T any](node Node[T]) (Definition, bool) {
+	switch n := node.(type) {
+	case *Sequence[T]:
+		return Definition{Type: "Sequence"}, true
+	case *Priority[T]:
+		return Definition{Type: "Priority"}, true
+	case *Random[T]:
+		return Definition{Type: "Random"}, true
+	case *WeightedRandom[T]:
+		return Definition{Type: "WeightedRandom", Params: Params{"weights": formatFloats(n.Weights)}}, true
+	case *ShuffleSelector[T]:
+		return Definition{Type: "ShuffleSelector"}, true
+	case *ShuffleSequence[T]:
+		return Definition{Type: "ShuffleSequence"}, true
+	case *ReactiveSequence[T]:
+		return Definition{Type: "ReactiveSequence"}, true
+	case *ReactiveFallback[T]:
+		return Definition{Type: "ReactiveFallback"}, true
+	case *Parallel[T]:
+		return Definition{Type: "Parallel", Params: Params{"success": n.SuccessThreshold, "failure": n.FailureThreshold}}, true
+	case *InvertDecorator[T]:
+		return Definition{Type: "Invert"}, true
+	case *AlwaysSucceedDecorator[T]:
+		return Definition{Type: "AlwaysSucceed"}, true
+	case *AlwaysFailDecorator[T]:
+		return Definition{Type: "AlwaysFail"}, true
+	case *UntilFailDecorator[T]:
+		return Definition{Type: "UntilFail"}, true
+	case *ScopeDecorator[T]:
+		return Definition{Type: "Scope"}, true
+	case *RepeatDecorator[T]:
+		return Definition{Type: "Repeat", Params: Params{"count": n.Count, "ignore_failure": n.IgnoreFailure}}, true
+	case *RetryDecorator[T]:
+		return Definition{Type: "Retry", Params: Params{"attempts": n.MaxAttempts}}, true
+	case *TimeoutDecorator[T]:
+		return Definition{Type: "Timeout", Params: Params{"timeout": n.Timeout.String()}}, true
+	case *CooldownDecorator[T]:
+		return Definition{Type: "Cooldown", Params: Params{"cooldown": n.Cooldown.String()}}, true
+	case *RateLimitDecorator[T]:
+		return Definition{Type: "RateLimit", Params: Params{"limit": n.Limit, "window": n.Window.String()}}, true
+	case *SubTree[T]:
+		params := Params{"tree": n.Tree}
+		for key, enclosingKey := range n.Remap {
+			params[key] = "{" + enclosingKey + "}"
+		}
+		return Definition{Type: "SubTree", Params: params}, true
+	}
+	return Definition{}, false
+}
+
+// formatFloats returns the numbers as a string of comma-separated numbers, as accepted by Params.Floats.
+func formatFloats(floats []float64) string {
+	formatted := make([]string, len(floats))
+	for i, f := range floats {
+		formatted[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strings.Join(formatted, ",")
+}
+
+// expectChildren returns an error unless there are exactly n children.
+func expectChildren[T any](children []Node[T], n int) error {
+	if len(children) != n {
+		return fmt.Errorf("%w: expected %d, got %d", ErrChildCount, n, len(children))
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package behaviortree
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newTestRegistry returns a registry with succeeding, failing and running tasks registered.
+func newTestRegistry() *Registry[int] {
+	registry := NewRegistry[int]()
+	registry.RegisterTask("Succeed", func(task *Task[int], obj int) { task.Success() })
+	registry.RegisterTask("Fail", func(task *Task[int], obj int) { task.Fail() })
+	registry.RegisterTask("Run", func(task *Task[int], obj int) { task.Running() })
+	return registry
+}
+
+func TestRegistry_BuildBuiltins(t *testing.T) {
+	registry := newTestRegistry()
+
+	cases := []struct {
+		definition Definition
+		status     Status
+	}{
+		{Definition{Type: "Sequence", Children: []Definition{{Type: "Succeed"}, {Type: "Fail"}}}, Failure},
+		{Definition{Type: "Priority", Children: []Definition{{Type: "Fail"}, {Type: "Succeed"}}}, Success},
+		{Definition{Type: "Random", Children: []Definition{{Type: "Fail"}}}, Failure},
+		{Definition{Type: "Parallel", Params: Params{"success": 1}, Children: []Definition{{Type: "Run"}, {Type: "Succeed"}}}, Success},
+		{Definition{Type: "Parallel", Children: []Definition{{Type: "Run"}, {Type: "Fail"}}}, Failure},
+		{Definition{Type: "Invert", Children: []Definition{{Type: "Succeed"}}}, Failure},
+		{Definition{Type: "AlwaysSucceed", Children: []Definition{{Type: "Fail"}}}, Success},
+		{Definition{Type: "AlwaysFail", Children: []Definition{{Type: "Succeed"}}}, Failure},
+		{Definition{Type: "UntilFail", Children: []Definition{{Type: "Fail"}}}, Success},
+		{Definition{Type: "Scope", Children: []Definition{{Type: "Succeed"}}}, Success},
+	}
+	for _, c := range cases {
+		node, err := registry.Build(c.definition)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.definition.Type, err)
+			continue
+		}
+		if status := TickNode(node, 0); status != c.status {
+			t.Errorf("%s: expected %v, but got %v", c.definition.Type, c.status, status)
+		}
+	}
+}
+
+func TestRegistry_BuildErrors(t *testing.T) {
+	registry := newTestRegistry()
+	registry.Register("Broken", func(params Params, children []Node[int]) (Node[int], error) {
+		return nil, errors.New("broken")
+	})
+
+	cases := []struct {
+		definition Definition
+		path       string
+		message    string
+	}{
+		{
+			Definition{Type: "Sequence", Children: []Definition{{Type: "Succeed"}, {Type: "Missing"}}},
+			"$.children[1].type",
+			`behaviortree: $.children[1].type: unknown node type "Missing"`,
+		},
+		{
+			Definition{Type: "Invert"},
+			"$.children",
+			"behaviortree: $.children: wrong number of children: expected 1, got 0",
+		},
+		{
+			Definition{Type: "Sequence", Children: []Definition{{Type: "Succeed", Children: []Definition{{Type: "Fail"}}}}},
+			"$.children[0].children",
+			"behaviortree: $.children[0].children: wrong number of children: expected 0, got 1",
+		},
+		{
+			Definition{Type: "Priority", Children: []Definition{{Type: "Parallel", Params: Params{"success": "all"}}}},
+			"$.children[0].params.success",
+			`behaviortree: $.children[0].params.success: parameter "success": expected an integer, got all`,
+		},
+		{
+			Definition{Type: "Parallel", Params: Params{"failure": true}},
+			"$.params.failure",
+			`behaviortree: $.params.failure: parameter "failure": expected an integer, got true`,
+		},
+		{
+			Definition{Type: "Broken"},
+			"$",
+			"behaviortree: $: broken",
+		},
+	}
+	for _, c := range cases {
+		_, err := registry.Build(c.definition)
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) {
+			t.Errorf("Expected a LoadError, but got %v", err)
+			continue
+		}
+		if loadErr.Path != c.path {
+			t.Errorf("Expected path %q, but got %q", c.path, loadErr.Path)
+		}
+		if err.Error() != c.message {
+			t.Errorf("Expected message %q, but got %q", c.message, err.Error())
+		}
+		if errors.Unwrap(err) == nil {
+			t.Error("Expected LoadError to wrap the underlying error")
+		}
+	}
+}
+
+func TestRegistry_CustomDecorator(t *testing.T) {
+	registry := newTestRegistry()
+	registry.RegisterDecorator("Double", func(node Node[int]) Node[int] {
+		return NewSequence([]Node[int]{node, node})
+	})
+
+	node, err := registry.Build(Definition{Type: "Double", Children: []Definition{{Type: "Succeed"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := TickNode(node, 0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+}
+
+func TestRegistry_ReplaceRegistration(t *testing.T) {
+	registry := newTestRegistry()
+	registry.RegisterTask("Succeed", func(task *Task[int], obj int) { task.Fail() })
+
+	node, err := registry.Build(Definition{Type: "Succeed"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := TickNode(node, 0); status != Failure {
+		t.Errorf("Expected the replaced factory to be used, but got %v", status)
+	}
+}
+
+func TestRegistry_DefinitionKeptByNode(t *testing.T) {
+	definition := Definition{Type: "Sequence", Children: []Definition{
+		{Type: "Repeat", Params: Params{"count": 2, "ignore_failure": false}, Children: []Definition{{Type: "Succeed"}}},
+	}}
+	node, err := newTestRegistry().Build(definition)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	clone, err := Clone(node)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A registry that did not build the nodes describes them from the definitions they keep.
+	described, err := NewRegistry[int]().Describe(clone)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(described, definition) {
+		t.Errorf("Expected %+v, but got %+v", definition, described)
+	}
+
+	// A custom type building a built-in node keeps its type, and params the fields do not tell are kept.
+	registry := newTestRegistry()
+	registry.Register("Patrol", func(params Params, children []Node[int]) (Node[int], error) {
+		return NewSequence(children), nil
+	})
+	for _, definition := range []Definition{
+		{Type: "Patrol", Params: Params{"route": "north"}},
+		{Type: "Sequence", Params: Params{"note": "kept"}},
+	} {
+		node, err := registry.Build(definition)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if described, err := registry.Describe(node); err != nil || !reflect.DeepEqual(described, definition) {
+			t.Errorf("Expected %+v, but got %+v (%v)", definition, described, err)
+		}
+	}
+}
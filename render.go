@@ -0,0 +1,126 @@
+package behaviortree
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RenderOptions configures WriteDOT and WriteMermaid.
+type RenderOptions[T any] struct {
+	// Status returns the status to color a node with, such as the status of its last tick.
+	// Nodes are not colored if Status is nil or returns Invalid.
+	Status func(node Node[T]) Status
+}
+
+// renderedNode is a node of the tree being rendered.
+type renderedNode struct {
+	id     string // The identifier of the node in the rendered graph.
+	parent string // The identifier of the parent node, empty for the root.
+	label  string // The label shown for the node.
+	kind   Kind   // The kind of the node, used to select its shape.
+	status Status // The status the node is colored with.
+}
+
+// renderNodes walks the tree rooted at root and returns its nodes in depth-first order.
+func renderNodes[T any](root Node[T], opts RenderOptions[T]) []renderedNode {
+	var nodes []renderedNode
+	var stack []string // The identifiers of the ancestors of the visited node by depth.
+	Walk(root, func(node Node[T], depth int) bool {
+		rendered := renderedNode{
+			id:    fmt.Sprintf("n%d", len(nodes)),
+			label: NameOf(node),
+			kind:  KindOf(node),
+		}
+		stack = append(stack[:depth], rendered.id)
+		if depth > 0 {
+			rendered.parent = stack[depth-1]
+		}
+		if opts.Status != nil {
+			rendered.status = opts.Status(node)
+		}
+		nodes = append(nodes, rendered)
+		return true
+	})
+	return nodes
+}
+
+// dotShapes are the Graphviz shapes of the node kinds.
+var dotShapes = map[Kind]string{
+	KindAction:    `shape=box`,
+	KindCondition: `shape=ellipse`,
+	KindComposite: `shape=box, style=rounded`,
+	KindDecorator: `shape=hexagon`,
+	KindTree:      `shape=folder`,
+}
+
+// statusColors are the fill colors of the node statuses.
+var statusColors = map[Status]string{
+	Success: "palegreen",
+	Failure: "salmon",
+	Running: "gold",
+}
+
+// WriteDOT writes the tree rooted at root as a Graphviz DOT digraph. Composites are drawn as rounded
+// boxes, decorators as hexagons, actions as boxes, conditions as ellipses and nested trees as folders.
+//
+//	behaviortree.WriteDOT(os.Stdout, tree, behaviortree.RenderOptions[*Dog]{})
+func WriteDOT[T any](w io.Writer, root Node[T], opts RenderOptions[T]) error {
+	var b bytes.Buffer
+	b.WriteString("digraph behaviortree {\n")
+	for _, node := range renderNodes(root, opts) {
+		attrs := dotShapes[node.kind]
+		if color, ok := statusColors[node.status]; ok {
+			if strings.Contains(attrs, "style=") {
+				attrs = strings.Replace(attrs, "style=rounded", `style="rounded,filled"`, 1)
+			} else {
+				attrs += ", style=filled"
+			}
+			attrs += ", fillcolor=" + color
+		}
+		fmt.Fprintf(&b, "  %s [label=%q, %s];\n", node.id, node.label, attrs)
+		if node.parent != "" {
+			fmt.Fprintf(&b, "  %s -> %s;\n", node.parent, node.id)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// mermaidShapes are the opening and closing brackets of the Mermaid shapes of the node kinds.
+var mermaidShapes = map[Kind][2]string{
+	KindAction:    {`["`, `"]`},
+	KindCondition: {`(["`, `"])`},
+	KindComposite: {`("`, `")`},
+	KindDecorator: {`{{"`, `"}}`},
+	KindTree:      {`[["`, `"]]`},
+}
+
+// WriteMermaid writes the tree rooted at root as a Mermaid flowchart, using the same styling as WriteDOT.
+func WriteMermaid[T any](w io.Writer, root Node[T], opts RenderOptions[T]) error {
+	var b bytes.Buffer
+	b.WriteString("flowchart TD\n")
+	used := make(map[Status]bool)
+	for _, node := range renderNodes(root, opts) {
+		shape := mermaidShapes[node.kind]
+		label := strings.ReplaceAll(node.label, `"`, "#quot;")
+		fmt.Fprintf(&b, "  %s%s%s%s", node.id, shape[0], label, shape[1])
+		if _, ok := statusColors[node.status]; ok {
+			fmt.Fprintf(&b, ":::%s", strings.ToLower(node.status.String()))
+			used[node.status] = true
+		}
+		b.WriteString("\n")
+		if node.parent != "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", node.parent, node.id)
+		}
+	}
+	for _, status := range []Status{Success, Failure, Running} {
+		if used[status] {
+			fmt.Fprintf(&b, "  classDef %s fill:%s\n", strings.ToLower(status.String()), statusColors[status])
+		}
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,122 @@
+package behaviortree
+
+import (
+	"bytes"
+	"testing"
+)
+
+// renderTree returns a tree with a composite, a decorator and actions, and the action that succeeded.
+func renderTree() (*BehaviorTree[int], Node[int]) {
+	succeeded := statusTask(Success)
+	root := NewPriority([]Node[int]{NewInvertDecorator[int](succeeded), statusTask(Running)})
+	return NewBehaviorTree[int](root), succeeded
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree, _ := renderTree()
+
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, tree, RenderOptions[int]{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `digraph behaviortree {
+  n0 [label="BehaviorTree", shape=folder];
+  n1 [label="Priority", shape=box, style=rounded];
+  n0 -> n1;
+  n2 [label="InvertDecorator", shape=hexagon];
+  n1 -> n2;
+  n3 [label="Task", shape=box];
+  n2 -> n3;
+  n4 [label="Task", shape=box];
+  n1 -> n4;
+}
+`
+	if b.String() != expected {
+		t.Errorf("Unexpected DOT output:\n%s", b.String())
+	}
+}
+
+func TestWriteDOT_Status(t *testing.T) {
+	tree, succeeded := renderTree()
+	opts := RenderOptions[int]{Status: func(node Node[int]) Status {
+		switch node {
+		case succeeded:
+			return Success
+		case tree.RootNode:
+			return Running
+		}
+		return Invalid
+	}}
+
+	var b bytes.Buffer
+	if err := WriteDOT[int](&b, tree, opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, line := range []string{
+		`n1 [label="Priority", shape=box, style="rounded,filled", fillcolor=gold];`,
+		`n3 [label="Task", shape=box, style=filled, fillcolor=palegreen];`,
+		`n4 [label="Task", shape=box];`,
+	} {
+		if !bytes.Contains(b.Bytes(), []byte(line)) {
+			t.Errorf("Expected output to contain %q:\n%s", line, b.String())
+		}
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	tree, succeeded := renderTree()
+	opts := RenderOptions[int]{Status: func(node Node[int]) Status {
+		if node == succeeded {
+			return Success
+		}
+		return Invalid
+	}}
+
+	var b bytes.Buffer
+	if err := WriteMermaid[int](&b, tree, opts); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `flowchart TD
+  n0[["BehaviorTree"]]
+  n1("Priority")
+  n0 --> n1
+  n2{{"InvertDecorator"}}
+  n1 --> n2
+  n3["Task"]:::success
+  n2 --> n3
+  n4["Task"]
+  n1 --> n4
+  classDef success fill:palegreen
+`
+	if b.String() != expected {
+		t.Errorf("Unexpected Mermaid output:\n%s", b.String())
+	}
+}
+
+func TestRender_WriteError(t *testing.T) {
+	tree, _ := renderTree()
+
+	if err := WriteDOT[int](failingWriter{}, tree, RenderOptions[int]{}); err == nil {
+		t.Error("Expected WriteDOT to report the write error")
+	}
+	if err := WriteMermaid[int](failingWriter{}, tree, RenderOptions[int]{}); err == nil {
+		t.Error("Expected WriteMermaid to report the write error")
+	}
+}
+
+func TestRender_Names(t *testing.T) {
+	tree := NewBehaviorTree[int](Named(NewSequence([]Node[int]{Named(statusTask(Success), `say "hi"`)}), "Greet"))
+
+	var b bytes.Buffer
+	if err := WriteMermaid[int](&b, tree, RenderOptions[int]{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, line := range []string{`n1("Greet")`, `n2["say #quot;hi#quot;"]`} {
+		if !bytes.Contains(b.Bytes(), []byte(line)) {
+			t.Errorf("Expected output to contain %q:\n%s", line, b.String())
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package behaviortree
+
+// RepeatForever is the count of a RepeatDecorator that repeats its child node until it is halted.
+const RepeatForever = 0
+
+// RepeatDecorator is a decorator node that runs its child node a number of times, or forever. Each time
+// the child node completes, the decorator reports Running and starts the next iteration on the next tick,
+// so at most one iteration completes per tick. The decorator succeeds once Count iterations have
+// completed. A failing child makes the decorator fail, unless failures are ignored.
+type RepeatDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	Count         int  // Number of iterations to run, or RepeatForever.
+	IgnoreFailure bool // Indicates whether a failing iteration counts as completed instead of failing.
+	Iteration     int  // Number of iterations completed so far.
+	NodeRunning   bool // Indicates whether the child node has been started and has not completed yet.
+}
+
+// NewRepeatDecorator creates a new RepeatDecorator that runs the specified child node count times, or
+// forever if count is RepeatForever. If ignoreFailure is set, failing iterations are counted as completed.
+func NewRepeatDecorator[T any](node Node[T], count int, ignoreFailure bool) *RepeatDecorator[T] {
+	decorator := &RepeatDecorator[T]{
+		Count:         count,
+		IgnoreFailure: ignoreFailure,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless it is in the middle of its iterations.
+func (d *RepeatDecorator[T]) Start(object T) {
+	if !d.NodeRunning && d.Iteration == 0 {
+		d.setObject(object)
+	}
+}
+
+// Run executes the child node, starting it first if a new iteration begins.
+func (d *RepeatDecorator[T]) Run(object T) {
+	if !d.NodeRunning {
+		d.NodeRunning = true
+		d.Node.Start(object)
+	}
+	d.Node.Run(object)
+}
+
+// Success is called when the child node succeeds. It completes the iteration.
+func (d *RepeatDecorator[T]) Success() {
+	d.complete()
+}
+
+// Fail is called when the child node fails. It completes the iteration if failures are ignored, and
+// signals failure to the control node otherwise.
+func (d *RepeatDecorator[T]) Fail() {
+	if d.IgnoreFailure {
+		d.complete()
+		return
+	}
+	d.NodeRunning = false
+	d.Iteration = 0
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// complete finishes the current iteration and signals success to the control node once every iteration
+// has completed, or Running if more iterations remain.
+func (d *RepeatDecorator[T]) complete() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	d.Iteration++
+	if d.Count > RepeatForever && d.Iteration >= d.Count {
+		d.Iteration = 0
+		if d.ControlNode != nil {
+			d.ControlNode.Success()
+		}
+		return
+	}
+	if d.ControlNode != nil {
+		d.ControlNode.Running()
+	}
+}
+
+// Halt interrupts the running child node and resets the iterations.
+func (d *RepeatDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+	d.Iteration = 0
+}
@@ -0,0 +1,149 @@
+package behaviortree
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tickStatuses ticks the node n times and returns the reported statuses.
+func tickStatuses(node Node[int], n int) []Status {
+	statuses := make([]Status, 0, n)
+	for i := 0; i < n; i++ {
+		statuses = append(statuses, TickNode(node, 0))
+	}
+	return statuses
+}
+
+func TestRepeatDecorator_Count(t *testing.T) {
+	child := newHaltableTask(Success)
+	repeat := NewRepeatDecorator[int](child, 3, false)
+
+	statuses := tickStatuses(repeat, 4)
+	expected := []Status{Running, Running, Success, Running}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.runs != 4 || child.started != 4 || repeat.Iteration != 1 {
+		t.Errorf("Expected one run per tick, got %d runs, %d starts and iteration %d", child.runs, child.started, repeat.Iteration)
+	}
+}
+
+func TestRepeatDecorator_ResumesRunningChild(t *testing.T) {
+	child := newHaltableTask(Running, Success, Running, Success)
+	tree := NewBehaviorTree[int](NewRepeatDecorator[int](child, 2, false))
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0), tree.Tick(0), tree.Tick(0)}
+	expected := []Status{Running, Running, Running, Success}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.started != 2 {
+		t.Errorf("Expected the child to be started once per iteration, but got %d", child.started)
+	}
+}
+
+func TestRepeatDecorator_Failure(t *testing.T) {
+	repeat := NewRepeatDecorator[int](statusTask(Success, Failure), 5, false)
+	if statuses := tickStatuses(repeat, 2); !reflect.DeepEqual(statuses, []Status{Running, Failure}) {
+		t.Errorf("Expected the failure to stop the loop, but got %v", statuses)
+	}
+	if repeat.Iteration != 0 {
+		t.Errorf("Expected the iterations to be reset, but got %d", repeat.Iteration)
+	}
+
+	ignoring := NewRepeatDecorator[int](statusTask(Failure), 2, true)
+	if statuses := tickStatuses(ignoring, 2); !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+		t.Errorf("Expected failures to be ignored, but got %v", statuses)
+	}
+}
+
+func TestRepeatDecorator_Forever(t *testing.T) {
+	child := newHaltableTask(Success, Failure, Running)
+	repeat := NewRepeatDecorator[int](child, RepeatForever, true)
+
+	if statuses := tickStatuses(repeat, 5); !reflect.DeepEqual(statuses, []Status{Running, Running, Running, Running, Running}) {
+		t.Errorf("Expected the decorator to keep running, but got %v", statuses)
+	}
+	if repeat.Iteration != 2 {
+		t.Errorf("Expected two completed iterations, but got %d", repeat.Iteration)
+	}
+
+	repeat.Halt(0)
+	repeat.Halt(0)
+	if child.halted != 1 || repeat.Iteration != 0 || repeat.NodeRunning {
+		t.Errorf("Expected Halt to interrupt the child once and reset, got %d halts", child.halted)
+	}
+}
+
+func TestRepeatDecorator_WithoutControl(t *testing.T) {
+	repeat := NewRepeatDecorator[int](statusTask(Success, Success, Failure), 2, false)
+	repeat.Start(0)
+	repeat.Run(0)
+	repeat.Run(0)
+	repeat.Run(0)
+	if repeat.Iteration != 0 || repeat.NodeRunning {
+		t.Error("Expected the decorator to work without a control node")
+	}
+}
+
+func TestRegistry_Repeat(t *testing.T) {
+	registry := newTestRegistry()
+
+	node, err := registry.Build(Definition{Type: "Repeat", Params: Params{"count": 2, "ignore_failure": true}, Children: []Definition{{Type: "Fail"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if statuses := tickStatuses(node, 2); !reflect.DeepEqual(statuses, []Status{Running, Success}) {
+		t.Errorf("Expected Running then Success, but got %v", statuses)
+	}
+
+	errorCases := []Definition{
+		{Type: "Repeat"},
+		{Type: "Repeat", Params: Params{"count": "many"}, Children: []Definition{{Type: "Fail"}}},
+		{Type: "Repeat", Params: Params{"ignore_failure": "sometimes"}, Children: []Definition{{Type: "Fail"}}},
+	}
+	for _, definition := range errorCases {
+		if _, err := registry.Build(definition); err == nil {
+			t.Errorf("Expected an error for %+v", definition)
+		}
+	}
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><Repeat num_cycles="3"><Succeed/></Repeat></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	described, err := registry.Describe(tree)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count, _ := described.Params.Int("count", 0); described.Type != "Repeat" || count != 3 {
+		t.Errorf("Expected a Repeat node with 3 cycles, but got %+v", described)
+	}
+	if definition, ok := describeBuiltin[int](NewRepeatDecorator[int](statusTask(Success), 4, true)); !ok || definition.Params["count"] != 4 {
+		t.Errorf("Expected the built-in Repeat node to be described, but got %+v", definition)
+	}
+	forever, err := registry.LoadXML([]byte(`<root><BehaviorTree><Repeat num_cycles="-1"><Succeed/></Repeat></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := forever.RootNode.(*RepeatDecorator[int]).Count; count != RepeatForever {
+		t.Errorf("Expected -1 cycles to be imported as RepeatForever, but got %d", count)
+	}
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, forever); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<Repeat num_cycles="-1"`) {
+		t.Errorf("Expected RepeatForever to be exported as -1, got:\n%s", buf.String())
+	}
+	forever.RootNode.(*RepeatDecorator[int]).Count = 5
+	buf.Reset()
+	if err := registry.WriteXML(&buf, forever); err != nil || !strings.Contains(buf.String(), `<Repeat num_cycles="5"`) {
+		t.Errorf("Expected the changed count to be exported, got %v:\n%s", err, buf.String())
+	}
+	if _, err := registry.LoadXML([]byte(`<root><BehaviorTree><Repeat num_cycles="0"><Succeed/></Repeat></BehaviorTree></root>`)); err == nil {
+		t.Error("Expected an error for zero cycles, which BehaviorTree.CPP does not repeat at all")
+	}
+}
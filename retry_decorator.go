@@ -0,0 +1,183 @@
+package behaviortree
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryForever is the maximum number of attempts of a RetryDecorator that retries its child node until
+// it succeeds.
+const RetryForever = 0
+
+// Backoff returns the delay to wait before retrying after the given number of failed attempts, starting at 1.
+type Backoff func(attempt int) time.Duration
+
+// FixedBackoff returns a Backoff waiting the same delay before every retry.
+func FixedBackoff(delay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a Backoff doubling the delay after every failed attempt, starting at initial
+// and capped at max. A max of zero or less caps the delay at the longest time.Duration instead, so that
+// the delay never overflows however many attempts have failed.
+func ExponentialBackoff(initial, max time.Duration) Backoff {
+	limit := max
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay > 0 && delay < limit; i++ {
+			if delay > limit/2 {
+				delay = limit
+				break
+			}
+			delay *= 2
+		}
+		if delay > limit {
+			delay = limit
+		}
+		return delay
+	}
+}
+
+// JitterBackoff returns a Backoff randomly spreading the delays of backoff by up to the given fraction
+// in either direction, so that many agents failing together do not retry in lockstep. If random is nil,
+// the global source of math/rand is used. The Backoff draws from random under a lock, so it is safe for
+// concurrent use and can be shared by the instances of a tree, as long as random is not used elsewhere.
+func JitterBackoff(backoff Backoff, fraction float64, random *rand.Rand) Backoff {
+	var mu sync.Mutex
+	return func(attempt int) time.Duration {
+		delay := float64(backoff(attempt))
+		mu.Lock()
+		spread := 2*randFloat64(random) - 1
+		mu.Unlock()
+		jittered := delay + delay*fraction*spread
+		if jittered >= math.MaxInt64 {
+			return math.MaxInt64
+		}
+		return time.Duration(jittered)
+	}
+}
+
+// RetryDecorator is a decorator node that runs its child node again when it fails, up to a maximum number
+// of attempts. Between attempts the decorator reports Running while it waits for the delay given by its
+// Backoff, so a failing child never blocks the tick. Delays are measured with Clock, or counted in ticks
+// if TickDuration is set. The decorator succeeds as soon as the child succeeds, and fails once the last
+// attempt has failed.
+type RetryDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	MaxAttempts  int           // Maximum number of attempts, or RetryForever.
+	Backoff      Backoff       // Delay before each retry, or nil to retry on the next tick.
+	Clock        Clock         // Measures the delays. If nil, the system clock is used.
+	TickDuration time.Duration // If positive, delays are counted in ticks of this duration instead of measured with Clock.
+
+	Attempt     int       // Number of failed attempts so far.
+	NodeRunning bool      // Indicates whether the child node has been started and has not completed yet.
+	Waiting     bool      // Indicates whether the decorator is waiting before the next attempt.
+	RetryAt     time.Time // The time of the next attempt when waiting on the clock.
+	WaitTicks   int       // The number of ticks left to wait when counting ticks.
+}
+
+// NewRetryDecorator creates a new RetryDecorator that makes up to maxAttempts attempts to run the specified
+// child node, or retries forever if maxAttempts is RetryForever, waiting as given by backoff between attempts.
+func NewRetryDecorator[T any](node Node[T], maxAttempts int, backoff Backoff) *RetryDecorator[T] {
+	decorator := &RetryDecorator[T]{
+		MaxAttempts: maxAttempts,
+		Backoff:     backoff,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless it is in the middle of its attempts.
+func (d *RetryDecorator[T]) Start(object T) {
+	if !d.NodeRunning && !d.Waiting {
+		d.setObject(object)
+	}
+}
+
+// Run waits for the delay before the next attempt, or executes the child node, starting it first if a
+// new attempt begins.
+func (d *RetryDecorator[T]) Run(object T) {
+	if d.Waiting {
+		if !d.waited() {
+			d.Running()
+			return
+		}
+		d.Waiting = false
+	}
+	if !d.NodeRunning {
+		d.NodeRunning = true
+		d.Node.Start(object)
+	}
+	d.Node.Run(object)
+}
+
+// waited reports whether the delay before the next attempt has passed.
+func (d *RetryDecorator[T]) waited() bool {
+	if d.TickDuration > 0 {
+		if d.WaitTicks > 0 {
+			d.WaitTicks--
+			return false
+		}
+		return true
+	}
+	return !clockOrSystem(d.Clock).Now().Before(d.RetryAt)
+}
+
+// Success is called when the child node succeeds. It signals success to the control node.
+func (d *RetryDecorator[T]) Success() {
+	d.NodeRunning = false
+	d.Attempt = 0
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails. It signals failure to the control node if no attempts are
+// left, and starts waiting for the next attempt otherwise.
+func (d *RetryDecorator[T]) Fail() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	d.Attempt++
+	if d.MaxAttempts > RetryForever && d.Attempt >= d.MaxAttempts {
+		d.Attempt = 0
+		if d.ControlNode != nil {
+			d.ControlNode.Fail()
+		}
+		return
+	}
+
+	var delay time.Duration
+	if d.Backoff != nil {
+		delay = d.Backoff(d.Attempt)
+	}
+	d.Waiting = true
+	if d.TickDuration > 0 {
+		d.WaitTicks = int(delay / d.TickDuration)
+		if delay%d.TickDuration != 0 {
+			d.WaitTicks++
+		}
+	} else {
+		d.RetryAt = clockOrSystem(d.Clock).Now().Add(delay)
+	}
+	d.Running()
+}
+
+// Halt interrupts the running child node and resets the attempts.
+func (d *RetryDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+	d.Waiting = false
+	d.Attempt = 0
+}
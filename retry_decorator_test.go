@@ -0,0 +1,222 @@
+package behaviortree
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffs(t *testing.T) {
+	fixed := FixedBackoff(time.Second)
+	if fixed(1) != time.Second || fixed(5) != time.Second {
+		t.Error("Expected a fixed delay")
+	}
+
+	exponential := ExponentialBackoff(100*time.Millisecond, time.Second)
+	var delays []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		delays = append(delays, exponential(attempt))
+	}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	if !reflect.DeepEqual(delays, expected) {
+		t.Errorf("Expected %v, but got %v", expected, delays)
+	}
+	if uncapped := ExponentialBackoff(time.Second, 0); uncapped(4) != 8*time.Second {
+		t.Errorf("Expected an uncapped delay of 8s, but got %v", uncapped(4))
+	}
+	for _, attempt := range []int{35, 64, 1000000} {
+		if delay := ExponentialBackoff(time.Second, 0)(attempt); delay != math.MaxInt64 {
+			t.Errorf("Expected the uncapped delay of attempt %d to stop at the longest duration, but got %v", attempt, delay)
+		}
+		if delay := JitterBackoff(ExponentialBackoff(time.Second, 0), 0.5, rand.New(rand.NewSource(1)))(attempt); delay <= 0 {
+			t.Errorf("Expected the jittered delay of attempt %d to stay positive, but got %v", attempt, delay)
+		}
+	}
+	if delay := ExponentialBackoff(0, 0)(1000000); delay != 0 {
+		t.Errorf("Expected a zero initial delay to stay zero, but got %v", delay)
+	}
+	if delay := ExponentialBackoff(2*time.Second, time.Second)(1); delay != time.Second {
+		t.Errorf("Expected an initial delay above the cap to be capped, but got %v", delay)
+	}
+
+	jitter := JitterBackoff(fixed, 0.5, rand.New(rand.NewSource(1)))
+	for attempt := 1; attempt <= 20; attempt++ {
+		if delay := jitter(attempt); delay < 500*time.Millisecond || delay > 1500*time.Millisecond {
+			t.Errorf("Expected the delay to stay within 50%% of 1s, but got %v", delay)
+		}
+	}
+	if delay := JitterBackoff(fixed, 0, nil)(1); delay != time.Second {
+		t.Errorf("Expected no jitter, but got %v", delay)
+	}
+}
+
+func TestRetryDecorator_SucceedsAfterFailures(t *testing.T) {
+	child := newHaltableTask(Failure, Failure, Success)
+	retry := NewRetryDecorator[int](child, 3, nil)
+
+	statuses := tickStatuses(retry, 3)
+	if !reflect.DeepEqual(statuses, []Status{Running, Running, Success}) {
+		t.Errorf("Expected Running, Running, Success, but got %v", statuses)
+	}
+	if child.started != 3 || retry.Attempt != 0 {
+		t.Errorf("Expected three attempts and a reset, got %d starts and attempt %d", child.started, retry.Attempt)
+	}
+}
+
+func TestRetryDecorator_GivesUp(t *testing.T) {
+	retry := NewRetryDecorator[int](statusTask(Failure), 2, nil)
+
+	statuses := tickStatuses(retry, 3)
+	if !reflect.DeepEqual(statuses, []Status{Running, Failure, Running}) {
+		t.Errorf("Expected Running, Failure, Running, but got %v", statuses)
+	}
+
+	forever := NewRetryDecorator[int](statusTask(Failure), RetryForever, nil)
+	if statuses := tickStatuses(forever, 3); !reflect.DeepEqual(statuses, []Status{Running, Running, Running}) {
+		t.Errorf("Expected the decorator to retry forever, but got %v", statuses)
+	}
+}
+
+func TestRetryDecorator_ClockBackoff(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	child := newHaltableTask(Failure, Running, Success)
+	retry := NewRetryDecorator[int](child, RetryForever, ExponentialBackoff(time.Second, 0))
+	retry.Clock = clock
+	tree := NewBehaviorTree[int](retry)
+
+	statuses := []Status{tree.Tick(0), tree.Tick(0)}
+	clock.Advance(999 * time.Millisecond)
+	statuses = append(statuses, tree.Tick(0))
+	clock.Advance(time.Millisecond)
+	statuses = append(statuses, tree.Tick(0), tree.Tick(0))
+
+	expected := []Status{Running, Running, Running, Running, Success}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.runs != 3 || child.started != 2 {
+		t.Errorf("Expected the child to wait a second before the second attempt, got %d runs and %d starts", child.runs, child.started)
+	}
+}
+
+func TestRetryDecorator_TickBackoff(t *testing.T) {
+	child := newHaltableTask(Failure, Success)
+	retry := NewRetryDecorator[int](child, 2, FixedBackoff(50*time.Millisecond))
+	retry.TickDuration = 20 * time.Millisecond
+
+	statuses := tickStatuses(retry, 5)
+	expected := []Status{Running, Running, Running, Running, Success}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected the retry to wait three ticks, but got %v", statuses)
+	}
+}
+
+func TestRetryDecorator_ManyAttempts(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	retry := NewRetryDecorator[int](statusTask(Failure), RetryForever, ExponentialBackoff(time.Second, 0))
+	retry.Clock = clock
+	retry.Attempt = 100
+
+	if statuses := tickStatuses(retry, 3); !reflect.DeepEqual(statuses, []Status{Running, Running, Running}) {
+		t.Errorf("Expected the decorator to keep waiting, but got %v", statuses)
+	}
+	if retry.Attempt != 101 || !retry.RetryAt.After(clock.Now()) {
+		t.Errorf("Expected a single attempt waiting far in the future, but got attempt %d at %v", retry.Attempt, retry.RetryAt)
+	}
+
+	ticks := NewRetryDecorator[int](statusTask(Failure), RetryForever, ExponentialBackoff(time.Second, 0))
+	ticks.TickDuration = time.Second
+	ticks.Attempt = 100
+	tickStatuses(ticks, 1)
+	if ticks.WaitTicks <= 0 {
+		t.Errorf("Expected a positive number of ticks to wait, but got %d", ticks.WaitTicks)
+	}
+}
+
+func TestRetryDecorator_SystemClock(t *testing.T) {
+	retry := NewRetryDecorator[int](statusTask(Failure, Success), 2, nil)
+	retry.Start(0)
+	retry.Run(0)
+	if !retry.Waiting || retry.RetryAt.IsZero() {
+		t.Fatal("Expected the decorator to wait on the system clock")
+	}
+	retry.Run(0)
+	if retry.Waiting || retry.Attempt != 0 {
+		t.Error("Expected the decorator to retry and succeed without a control node")
+	}
+}
+
+func TestRetryDecorator_Halt(t *testing.T) {
+	child := newHaltableTask(Running)
+	retry := NewRetryDecorator[int](child, 3, FixedBackoff(time.Hour))
+
+	TickNode[int](retry, 0)
+	retry.Halt(0)
+	retry.Halt(0)
+	if child.halted != 1 || retry.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+
+	waiting := NewRetryDecorator[int](statusTask(Failure), 3, FixedBackoff(time.Hour))
+	TickNode[int](waiting, 0)
+	waiting.Halt(0)
+	if waiting.Waiting || waiting.Attempt != 0 {
+		t.Error("Expected Halt to stop waiting and reset the attempts")
+	}
+}
+
+func TestRegistry_Retry(t *testing.T) {
+	registry := newTestRegistry()
+
+	node, err := registry.Build(Definition{Type: "Retry", Params: Params{"attempts": 2, "delay": "1h"}, Children: []Definition{{Type: "Fail"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if retry := node.(*RetryDecorator[int]); retry.MaxAttempts != 2 || retry.Backoff(1) != time.Hour {
+		t.Errorf("Unexpected decorator %+v", retry)
+	}
+
+	errorCases := []Definition{
+		{Type: "Retry"},
+		{Type: "Retry", Params: Params{"attempts": "many"}, Children: []Definition{{Type: "Fail"}}},
+		{Type: "Retry", Params: Params{"delay": "soon"}, Children: []Definition{{Type: "Fail"}}},
+	}
+	for _, definition := range errorCases {
+		if _, err := registry.Build(definition); err == nil {
+			t.Errorf("Expected an error for %+v", definition)
+		}
+	}
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><RetryUntilSuccessful num_attempts="3"><Fail/></RetryUntilSuccessful></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if retry := tree.RootNode.(*RetryDecorator[int]); retry.MaxAttempts != 3 || retry.Backoff != nil {
+		t.Errorf("Unexpected decorator %+v", retry)
+	}
+	described, err := registry.Describe(node)
+	if err != nil || described.Params["delay"] != "1h" {
+		t.Errorf("Expected a built Retry node to keep its delay, but got %+v (%v)", described, err)
+	}
+
+	forever, err := registry.LoadXML([]byte(`<root><BehaviorTree><RetryUntilSuccessful num_attempts="-1"><Fail/></RetryUntilSuccessful></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if retry := forever.RootNode.(*RetryDecorator[int]); retry.MaxAttempts != RetryForever {
+		t.Errorf("Expected -1 attempts to be imported as RetryForever, but got %d", retry.MaxAttempts)
+	}
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, forever); err != nil || !strings.Contains(buf.String(), `num_attempts="-1"`) {
+		t.Errorf("Expected RetryForever to be exported as -1, got %v:\n%s", err, buf.String())
+	}
+
+	definition, ok := describeBuiltin[int](NewRetryDecorator[int](statusTask(Success), 4, nil))
+	if !ok || definition.Params["attempts"] != 4 {
+		t.Errorf("Expected the built-in Retry node to be described, but got %+v", definition)
+	}
+}
@@ -0,0 +1,150 @@
+package behaviortree
+
+import "time"
+
+// Scheduler ticks many behavior trees, such as the instances of the agents of a simulation. Each tree
+// is ticked at its own interval, and a frame can be given a time budget: when a frame runs out of
+// budget, the trees it did not reach are ticked first in the next frame, so that every tree gets its
+// turn. A Scheduler is not safe for concurrent use.
+type Scheduler[T any] struct {
+	Budget time.Duration // The time a frame may spend ticking trees, or zero for no limit.
+	Clock  Clock         // Tells the time of the frames and measures the budget. If nil, the system clock is used.
+
+	entries []*ScheduledTree[T] // The scheduled trees in round-robin order.
+	next    int                 // The index of the entry the next frame starts at.
+	removed bool                // Indicates whether entries were removed since the last frame.
+	ticking bool                // Indicates whether a frame is being ticked.
+	halting []*ScheduledTree[T] // The entries removed during the frame, halted once it ends.
+}
+
+// ScheduledTree is a tree managed by a Scheduler.
+type ScheduledTree[T any] struct {
+	Tree       *BehaviorTree[T] // The scheduled tree.
+	Object     T                // The object the tree is ticked with.
+	Interval   time.Duration    // The time between two ticks of the tree, or zero to tick it every frame.
+	NextTick   time.Time        // The time from which the tree is due for its next tick.
+	LastStatus Status           // The status returned by the last tick of the tree.
+	Paused     bool             // Indicates whether the tree is skipped until it is resumed.
+
+	removed bool // Indicates whether the tree was removed from the scheduler.
+}
+
+// NewScheduler creates a new Scheduler with the given frame budget, or zero for no limit.
+func NewScheduler[T any](budget time.Duration) *Scheduler[T] {
+	return &Scheduler[T]{
+		Budget: budget,
+	}
+}
+
+// Add schedules the tree to be ticked with the object every interval, starting with the next frame.
+// An interval of zero ticks the tree every frame; a frequency of n ticks per second is an interval of
+// time.Second / n.
+func (s *Scheduler[T]) Add(tree *BehaviorTree[T], object T, interval time.Duration) *ScheduledTree[T] {
+	entry := &ScheduledTree[T]{
+		Tree:     tree,
+		Object:   object,
+		Interval: interval,
+	}
+	s.entries = append(s.entries, entry)
+	return entry
+}
+
+// Pause stops ticking the tree until it is resumed. The tree is not halted, so that it continues where
+// it left off when it is resumed.
+func (s *Scheduler[T]) Pause(entry *ScheduledTree[T]) {
+	entry.Paused = true
+}
+
+// Resume ticks the paused tree again, starting with the next frame.
+func (s *Scheduler[T]) Resume(entry *ScheduledTree[T]) {
+	entry.Paused = false
+}
+
+// Remove halts the tree and stops scheduling it. Trees can be removed while a frame is being ticked,
+// for example by one of the trees; they are then halted at the end of the frame, so that a tree removing
+// itself is not halted in the middle of its own tick.
+func (s *Scheduler[T]) Remove(entry *ScheduledTree[T]) {
+	if entry.removed {
+		return
+	}
+	entry.removed = true
+	s.removed = true
+	if s.ticking {
+		s.halting = append(s.halting, entry)
+		return
+	}
+	entry.Tree.Halt(entry.Object)
+}
+
+// Len returns the number of scheduled trees, including the paused ones.
+func (s *Scheduler[T]) Len() int {
+	s.compact()
+	return len(s.entries)
+}
+
+// Tick runs a frame: it ticks every tree that is due, in round-robin order, until the budget of the frame
+// is spent, and returns the number of trees it ticked. The next frame starts at the first tree this frame
+// did not reach.
+func (s *Scheduler[T]) Tick() int {
+	s.compact()
+	s.ticking = true
+	defer s.endFrame()
+
+	clock := clockOrSystem(s.Clock)
+	start := clock.Now()
+	ticked := 0
+	n := len(s.entries)
+	for i := 0; i < n; i++ {
+		index := (s.next + i) % n
+		entry := s.entries[index]
+		if entry.Paused || entry.removed || start.Before(entry.NextTick) {
+			continue
+		}
+		entry.LastStatus = entry.Tree.Tick(entry.Object)
+		entry.NextTick = start.Add(entry.Interval)
+		ticked++
+		if s.Budget > 0 && clock.Now().Sub(start) >= s.Budget {
+			s.next = (index + 1) % n
+			return ticked
+		}
+	}
+	return ticked
+}
+
+// endFrame ends the frame being ticked and halts the trees removed during it.
+func (s *Scheduler[T]) endFrame() {
+	s.ticking = false
+	halting := s.halting
+	s.halting = nil
+	for _, entry := range halting {
+		entry.Tree.Halt(entry.Object)
+	}
+}
+
+// compact drops the removed entries, keeping the round-robin position of the next frame. Entries are
+// not dropped while a frame is being ticked.
+func (s *Scheduler[T]) compact() {
+	if !s.removed || s.ticking {
+		return
+	}
+	s.removed = false
+	kept := s.entries[:0]
+	next := 0
+	for i, entry := range s.entries {
+		if entry.removed {
+			continue
+		}
+		if i < s.next {
+			next++
+		}
+		kept = append(kept, entry)
+	}
+	for i := len(kept); i < len(s.entries); i++ {
+		s.entries[i] = nil
+	}
+	s.entries = kept
+	s.next = next
+	if s.next >= len(s.entries) {
+		s.next = 0
+	}
+}
@@ -0,0 +1,156 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// countingTree returns a tree whose single task appends id to order and advances the clock by cost.
+func countingTree(id int, order *[]int, clock *ManualClock, cost time.Duration) *BehaviorTree[int] {
+	return NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) {
+		*order = append(*order, id)
+		clock.Advance(cost)
+		task.Success()
+	}))
+}
+
+func TestScheduler_Intervals(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	scheduler := NewScheduler[int](0)
+	scheduler.Clock = clock
+	var order []int
+	every := scheduler.Add(countingTree(0, &order, clock, 0), 0, 0)
+	scheduler.Add(countingTree(1, &order, clock, 0), 0, 100*time.Millisecond)
+
+	var ticked []int
+	for i := 0; i < 5; i++ {
+		ticked = append(ticked, scheduler.Tick())
+		clock.Advance(50 * time.Millisecond)
+	}
+	if !reflect.DeepEqual(ticked, []int{2, 1, 2, 1, 2}) {
+		t.Errorf("Expected the second tree to be ticked every other frame, but got %v", ticked)
+	}
+	if every.LastStatus != Success {
+		t.Errorf("Expected the status of the last tick to be kept, but got %v", every.LastStatus)
+	}
+}
+
+func TestScheduler_BudgetCarriesOver(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	scheduler := NewScheduler[int](25 * time.Millisecond)
+	scheduler.Clock = clock
+	var order []int
+	for i := 0; i < 5; i++ {
+		scheduler.Add(countingTree(i, &order, clock, 10*time.Millisecond), 0, 0)
+	}
+
+	frames := []int{scheduler.Tick(), scheduler.Tick(), scheduler.Tick()}
+	if !reflect.DeepEqual(frames, []int{3, 3, 3}) {
+		t.Errorf("Expected 3 trees per frame, but got %v", frames)
+	}
+	if expected := []int{0, 1, 2, 3, 4, 0, 1, 2, 3}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected the trees to be ticked in round-robin order %v, but got %v", expected, order)
+	}
+}
+
+func TestScheduler_PauseResume(t *testing.T) {
+	scheduler := NewScheduler[int](0)
+	var order []int
+	first := scheduler.Add(countingTree(0, &order, NewManualClock(time.Time{}), 0), 0, 0)
+	scheduler.Add(countingTree(1, &order, NewManualClock(time.Time{}), 0), 0, 0)
+
+	scheduler.Pause(first)
+	scheduler.Tick()
+	scheduler.Resume(first)
+	scheduler.Tick()
+	if !reflect.DeepEqual(order, []int{1, 0, 1}) {
+		t.Errorf("Expected the paused tree to be skipped, but got %v", order)
+	}
+	if scheduler.Len() != 2 {
+		t.Errorf("Expected paused trees to stay scheduled, but got %d", scheduler.Len())
+	}
+}
+
+func TestScheduler_Remove(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	scheduler := NewScheduler[int](15 * time.Millisecond)
+	scheduler.Clock = clock
+	var order []int
+	running := newHaltableTask(Running)
+	entries := []*ScheduledTree[int]{
+		scheduler.Add(NewBehaviorTree[int](running), 0, 0),
+		scheduler.Add(countingTree(1, &order, clock, 10*time.Millisecond), 0, 0),
+		scheduler.Add(countingTree(2, &order, clock, 10*time.Millisecond), 0, 0),
+	}
+	var last *ScheduledTree[int]
+	last = scheduler.Add(NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) {
+		order = append(order, 3)
+		scheduler.Remove(last)
+		if scheduler.Len() != 3 {
+			t.Error("Expected the entries to be kept while the frame is ticked")
+		}
+		task.Success()
+	})), 0, 0)
+
+	scheduler.Tick()
+	scheduler.Remove(entries[0])
+	scheduler.Remove(entries[0])
+	if running.halted != 1 {
+		t.Errorf("Expected the running tree to be halted once, but got %d", running.halted)
+	}
+	if scheduler.Len() != 3 {
+		t.Errorf("Expected 3 trees, but got %d", scheduler.Len())
+	}
+	scheduler.Tick()
+	scheduler.Tick()
+	if expected := []int{1, 2, 3, 1, 2, 1, 2}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected %v, but got %v", expected, order)
+	}
+	if scheduler.Len() != 2 {
+		t.Errorf("Expected the tree removing itself to be dropped, but got %d trees", scheduler.Len())
+	}
+
+	scheduler.Remove(entries[1])
+	scheduler.Remove(entries[2])
+	if scheduler.Len() != 0 || scheduler.Tick() != 0 {
+		t.Error("Expected an empty scheduler")
+	}
+}
+
+func TestScheduler_RemoveItself(t *testing.T) {
+	scheduler := NewScheduler[int](0)
+	var entry *ScheduledTree[int]
+	task := newHaltableTask()
+	halted := -1
+	task.RunFunc = func(t *Task[int], obj int) {
+		scheduler.Remove(entry)
+		halted = task.halted
+		t.Running()
+	}
+	entry = scheduler.Add(NewBehaviorTree[int](NewSequence([]Node[int]{task, statusTask(Success)})), 0, 0)
+
+	if scheduler.Tick() != 1 || entry.LastStatus != Running {
+		t.Errorf("Expected the tree to finish its tick, but got %v", entry.LastStatus)
+	}
+	if halted != 0 {
+		t.Error("Expected the tree not to be halted during its own tick")
+	}
+	if task.halted != 1 || entry.Tree.Started {
+		t.Errorf("Expected the tree to be halted at the end of the frame, but got %d halts", task.halted)
+	}
+	if scheduler.Len() != 0 || scheduler.Tick() != 0 {
+		t.Error("Expected the tree to be dropped")
+	}
+}
+
+func TestScheduler_SystemClock(t *testing.T) {
+	scheduler := NewScheduler[int](time.Hour)
+	entry := scheduler.Add(NewBehaviorTree[int](statusTask(Success)), 0, time.Hour)
+	if scheduler.Tick() != 1 || scheduler.Tick() != 0 {
+		t.Error("Expected the tree to be ticked once an hour")
+	}
+	if entry.NextTick.IsZero() {
+		t.Error("Expected the next tick to be scheduled on the system clock")
+	}
+}
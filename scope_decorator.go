@@ -0,0 +1,32 @@
+package behaviortree
+
+// ScopeDecorator is a decorator node that gives its subtree a child scope of the blackboard visible to
+// the decorator. Nodes in the subtree can read entries of the enclosing scopes, while their writes stay
+// local to the subtree.
+type ScopeDecorator[T any] struct {
+	Decorator[T]             // Embeds the Decorator structure to wrap a single child node.
+	scope        *Blackboard // The scope handed to the subtree.
+}
+
+// NewScopeDecorator creates a new ScopeDecorator with the specified child node.
+func NewScopeDecorator[T any](node Node[T]) *ScopeDecorator[T] {
+	decorator := &ScopeDecorator[T]{}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Blackboard returns the scope of the subtree. The scope is created on first use as a child of the
+// blackboard provided by the control node, or as a root blackboard if there is none. It is recreated
+// if the decorator is moved under a different blackboard.
+func (d *ScopeDecorator[T]) Blackboard() *Blackboard {
+	parent := d.Decorator.Blackboard()
+	if d.scope == nil || d.scope.Parent() != parent {
+		if parent != nil {
+			d.scope = parent.NewScope()
+		} else {
+			d.scope = NewBlackboard()
+		}
+	}
+	return d.scope
+}
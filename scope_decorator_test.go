@@ -0,0 +1,47 @@
+package behaviortree
+
+import "testing"
+
+func TestScopeDecorator_IsolatesWrites(t *testing.T) {
+	target := NewKey[string]("target")
+	var inside string
+
+	scoped := NewScopeDecorator[int](NewTask(func(task *Task[int], obj int) {
+		inside, _ = target.Get(task.Blackboard())
+		target.Set(task.Blackboard(), "local")
+		task.Success()
+	}))
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{scoped}))
+	target.Set(tree.Blackboard(), "global")
+
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if inside != "global" {
+		t.Errorf("Expected subtree to read the enclosing scope, but got %q", inside)
+	}
+	if value, _ := target.Get(tree.Blackboard()); value != "global" {
+		t.Errorf("Expected tree blackboard to be unaffected, but got %q", value)
+	}
+	if value, _ := target.Get(scoped.Blackboard()); value != "local" {
+		t.Errorf("Expected subtree scope to keep its write, but got %q", value)
+	}
+}
+
+func TestScopeDecorator_FollowsParentBlackboard(t *testing.T) {
+	scoped := NewScopeDecorator[int](NewTask(func(task *Task[int], obj int) {}))
+
+	root := scoped.Blackboard()
+	if root == nil || root.Parent() != nil {
+		t.Error("Expected a root scope without a parent blackboard")
+	}
+	if scoped.Blackboard() != root {
+		t.Error("Expected scope to be reused")
+	}
+
+	tree := NewBehaviorTree[int](scoped)
+	scoped.SetControl(tree)
+	if scoped.Blackboard().Parent() != tree.Blackboard() {
+		t.Error("Expected scope to be recreated under the tree blackboard")
+	}
+}
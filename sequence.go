@@ -2,11 +2,15 @@ package behaviortree
 
 // Sequence represents a composite node in the behavior tree that executes its child nodes sequentially.
 // If one child fails, the sequence fails. If all children succeed, the sequence succeeds.
+// When a child reports Running, the sequence resumes at that child on the next tick.
 type Sequence[T any] struct {
 	ControlNode Node[T] // The control node managing the sequence's behavior in the tree.
 	Nodes       []Node[T] // The list of child nodes to execute in sequence.
 	ActualTask  int // The index of the currently executing child node.
+	NodeRunning bool // Indicates whether the current child node is running.
 	Object      T // The object shared across nodes during execution.
+	Identity // The optional name and the path ID of the node.
+	Origin // The definition a Registry built the node from, if any.
 }
 
 // NewSequence creates a new Sequence node with the provided child nodes.
@@ -21,8 +25,12 @@ func (s *Sequence[T]) SetControl(control Node[T]) {
 	s.ControlNode = control
 }
 
-// Start initializes the sequence and its child nodes with the provided object.
+// Start initializes the sequence and its child nodes with the provided object. A running sequence
+// is left as is, so that it resumes at the running child.
 func (s *Sequence[T]) Start(object T) {
+	if s.NodeRunning {
+		return
+	}
 	s.Object = object
 	s.ActualTask = 0
 	for _, node := range s.Nodes {
@@ -45,6 +53,7 @@ func (s *Sequence[T]) Run(object T) {
 // Success is called when a child node succeeds. It advances to the next child node
 // or signals success to the control node if all children have succeeded.
 func (s *Sequence[T]) Success() {
+	s.NodeRunning = false
 	s.ActualTask++
 	if s.ActualTask < len(s.Nodes) {
 		s.Run(s.Object)
@@ -57,6 +66,7 @@ func (s *Sequence[T]) Success() {
 
 // Fail is called when a child node fails. It signals failure to the control node.
 func (s *Sequence[T]) Fail() {
+	s.NodeRunning = false
 	if s.ControlNode != nil {
 		s.ControlNode.Fail()
 	}
@@ -69,7 +79,37 @@ func (s *Sequence[T]) Finish(object T) {
 
 // Running signals that the sequence is still in progress to the control node.
 func (s *Sequence[T]) Running() {
+	s.NodeRunning = true
 	if s.ControlNode != nil {
 		s.ControlNode.Running()
 	}
-}
\ No newline at end of file
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (s *Sequence[T]) Blackboard() *Blackboard {
+	return blackboardOf(s.ControlNode)
+}
+
+// Children returns the child nodes of the Sequence.
+func (s *Sequence[T]) Children() []Node[T] {
+	return s.Nodes
+}
+
+// Kind reports that the Sequence is a composite node.
+func (s *Sequence[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (s *Sequence[T]) replaceChild(i int, node Node[T]) {
+	s.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the sequence.
+func (s *Sequence[T]) Halt(object T) {
+	if s.NodeRunning && s.ActualTask < len(s.Nodes) {
+		Halt(s.Nodes[s.ActualTask], object)
+	}
+	s.NodeRunning = false
+	s.ActualTask = 0
+}
@@ -0,0 +1,251 @@
+package behaviortree
+
+import "math/rand"
+
+// ShuffleSelector represents a composite node that tries its child nodes in a random order, like a Priority
+// node whose children are shuffled each time it starts. If a child node fails, the node moves to the next
+// child in the shuffled order; if a child succeeds, the node succeeds, and it fails once every child has
+// failed. When a child reports Running, the node resumes at that child on the next tick.
+type ShuffleSelector[T any] struct {
+	ControlNode Node[T]    // The control node managing this ShuffleSelector node.
+	Nodes       []Node[T]  // The list of child nodes to shuffle.
+	Rand        *rand.Rand // The source of the random order, or nil to use the global source of math/rand.
+	Order       []int      // The indexes of the child nodes in the order shuffled when the node started.
+	ActualTask  int        // The position in Order of the currently executing child node.
+	NodeRunning bool       // Indicates whether the current child node is running.
+	Object      T          // The object shared across nodes during execution.
+	Identity               // The optional name and the path ID of the node.
+	Origin                 // The definition a Registry built the node from, if any.
+}
+
+// NewShuffleSelector creates a new ShuffleSelector node with the specified child nodes.
+func NewShuffleSelector[T any](nodes []Node[T]) *ShuffleSelector[T] {
+	return &ShuffleSelector[T]{
+		Nodes: nodes,
+	}
+}
+
+// SetControl sets the control node for the ShuffleSelector node.
+func (s *ShuffleSelector[T]) SetControl(control Node[T]) {
+	s.ControlNode = control
+}
+
+// SetRand sets the source of the random order of the ShuffleSelector node.
+func (s *ShuffleSelector[T]) SetRand(random *rand.Rand) {
+	s.Rand = random
+}
+
+// Start initializes the ShuffleSelector node with the provided object and shuffles its child nodes.
+// A running ShuffleSelector node is left as is, so that it resumes at the running child.
+func (s *ShuffleSelector[T]) Start(object T) {
+	if !s.NodeRunning {
+		s.Object = object
+		s.ActualTask = 0
+		s.Order = shuffle(s.Order, len(s.Nodes), s.Rand)
+	}
+}
+
+// Run executes the current child node. A ShuffleSelector node without child nodes fails.
+func (s *ShuffleSelector[T]) Run(object T) {
+	if s.ActualTask >= len(s.Order) {
+		if s.ControlNode != nil {
+			s.ControlNode.Fail()
+		}
+		return
+	}
+	currentNode := s.Nodes[s.Order[s.ActualTask]]
+	currentNode.SetControl(s)
+	if !s.NodeRunning {
+		currentNode.Start(object)
+	}
+	currentNode.Run(object)
+}
+
+// Success is called when a child node succeeds. It signals success to the control node.
+func (s *ShuffleSelector[T]) Success() {
+	s.NodeRunning = false
+	if s.ControlNode != nil {
+		s.ControlNode.Success()
+	}
+}
+
+// Fail is called when a child node fails. It moves to the next child node in the shuffled order, or
+// signals failure to the control node if every child has failed.
+func (s *ShuffleSelector[T]) Fail() {
+	s.NodeRunning = false
+	s.ActualTask++
+	s.Run(s.Object)
+}
+
+// Finish is a placeholder method for when the ShuffleSelector node finishes execution.
+func (s *ShuffleSelector[T]) Finish(object T) {
+}
+
+// Running signals that the ShuffleSelector node is still in progress to the control node.
+func (s *ShuffleSelector[T]) Running() {
+	s.NodeRunning = true
+	if s.ControlNode != nil {
+		s.ControlNode.Running()
+	}
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (s *ShuffleSelector[T]) Blackboard() *Blackboard {
+	return blackboardOf(s.ControlNode)
+}
+
+// Children returns the child nodes of the ShuffleSelector node in their original order.
+func (s *ShuffleSelector[T]) Children() []Node[T] {
+	return s.Nodes
+}
+
+// Kind reports that the ShuffleSelector node is a composite node.
+func (s *ShuffleSelector[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (s *ShuffleSelector[T]) replaceChild(i int, node Node[T]) {
+	s.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the ShuffleSelector node.
+func (s *ShuffleSelector[T]) Halt(object T) {
+	if s.NodeRunning && s.ActualTask < len(s.Order) {
+		Halt(s.Nodes[s.Order[s.ActualTask]], object)
+	}
+	s.NodeRunning = false
+	s.ActualTask = 0
+}
+
+// ShuffleSequence represents a composite node that executes its child nodes in a random order, like a
+// Sequence whose children are shuffled each time it starts. If a child node fails, the node fails; it
+// succeeds once every child has succeeded. When a child reports Running, the node resumes at that child
+// on the next tick.
+type ShuffleSequence[T any] struct {
+	ControlNode Node[T]    // The control node managing this ShuffleSequence node.
+	Nodes       []Node[T]  // The list of child nodes to shuffle.
+	Rand        *rand.Rand // The source of the random order, or nil to use the global source of math/rand.
+	Order       []int      // The indexes of the child nodes in the order shuffled when the node started.
+	ActualTask  int        // The position in Order of the currently executing child node.
+	NodeRunning bool       // Indicates whether the current child node is running.
+	Object      T          // The object shared across nodes during execution.
+	Identity               // The optional name and the path ID of the node.
+	Origin                 // The definition a Registry built the node from, if any.
+}
+
+// NewShuffleSequence creates a new ShuffleSequence node with the specified child nodes.
+func NewShuffleSequence[T any](nodes []Node[T]) *ShuffleSequence[T] {
+	return &ShuffleSequence[T]{
+		Nodes: nodes,
+	}
+}
+
+// SetControl sets the control node for the ShuffleSequence node.
+func (s *ShuffleSequence[T]) SetControl(control Node[T]) {
+	s.ControlNode = control
+}
+
+// SetRand sets the source of the random order of the ShuffleSequence node.
+func (s *ShuffleSequence[T]) SetRand(random *rand.Rand) {
+	s.Rand = random
+}
+
+// Start initializes the ShuffleSequence node with the provided object and shuffles its child nodes.
+// A running ShuffleSequence node is left as is, so that it resumes at the running child.
+func (s *ShuffleSequence[T]) Start(object T) {
+	if !s.NodeRunning {
+		s.Object = object
+		s.ActualTask = 0
+		s.Order = shuffle(s.Order, len(s.Nodes), s.Rand)
+	}
+}
+
+// Run executes the current child node. A ShuffleSequence node without child nodes succeeds.
+func (s *ShuffleSequence[T]) Run(object T) {
+	if s.ActualTask >= len(s.Order) {
+		if s.ControlNode != nil {
+			s.ControlNode.Success()
+		}
+		return
+	}
+	currentNode := s.Nodes[s.Order[s.ActualTask]]
+	currentNode.SetControl(s)
+	if !s.NodeRunning {
+		currentNode.Start(object)
+	}
+	currentNode.Run(object)
+}
+
+// Success is called when a child node succeeds. It moves to the next child node in the shuffled order,
+// or signals success to the control node if every child has succeeded.
+func (s *ShuffleSequence[T]) Success() {
+	s.NodeRunning = false
+	s.ActualTask++
+	s.Run(s.Object)
+}
+
+// Fail is called when a child node fails. It signals failure to the control node.
+func (s *ShuffleSequence[T]) Fail() {
+	s.NodeRunning = false
+	if s.ControlNode != nil {
+		s.ControlNode.Fail()
+	}
+}
+
+// Finish is a placeholder method for when the ShuffleSequence node finishes execution.
+func (s *ShuffleSequence[T]) Finish(object T) {
+}
+
+// Running signals that the ShuffleSequence node is still in progress to the control node.
+func (s *ShuffleSequence[T]) Running() {
+	s.NodeRunning = true
+	if s.ControlNode != nil {
+		s.ControlNode.Running()
+	}
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (s *ShuffleSequence[T]) Blackboard() *Blackboard {
+	return blackboardOf(s.ControlNode)
+}
+
+// Children returns the child nodes of the ShuffleSequence node in their original order.
+func (s *ShuffleSequence[T]) Children() []Node[T] {
+	return s.Nodes
+}
+
+// Kind reports that the ShuffleSequence node is a composite node.
+func (s *ShuffleSequence[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (s *ShuffleSequence[T]) replaceChild(i int, node Node[T]) {
+	s.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the ShuffleSequence node.
+func (s *ShuffleSequence[T]) Halt(object T) {
+	if s.NodeRunning && s.ActualTask < len(s.Order) {
+		Halt(s.Nodes[s.Order[s.ActualTask]], object)
+	}
+	s.NodeRunning = false
+	s.ActualTask = 0
+}
+
+// shuffle fills order with a random permutation of the indexes of n child nodes drawn from random, or
+// from the global source if random is nil, reusing the storage of order.
+func shuffle(order []int, n int, random *rand.Rand) []int {
+	order = order[:0]
+	for i := 0; i < n; i++ {
+		order = append(order, i)
+	}
+	swap := func(i, j int) { order[i], order[j] = order[j], order[i] }
+	if random == nil {
+		rand.Shuffle(n, swap)
+	} else {
+		random.Shuffle(n, swap)
+	}
+	return order
+}
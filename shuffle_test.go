@@ -0,0 +1,166 @@
+package behaviortree
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// recordingTasks returns n tasks reporting the given status that append their index to order when run.
+func recordingTasks(n int, status Status, order *[]int) []Node[int] {
+	nodes := make([]Node[int], n)
+	for i := range nodes {
+		i := i
+		nodes[i] = NewTask(func(task *Task[int], obj int) {
+			*order = append(*order, i)
+			task.Report(status)
+		})
+	}
+	return nodes
+}
+
+// isPermutation reports whether order holds every index below n exactly once.
+func isPermutation(order []int, n int) bool {
+	seen := make(map[int]bool)
+	for _, i := range order {
+		if i < 0 || i >= n || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return len(seen) == n
+}
+
+func TestShuffleSelector_TriesEveryChild(t *testing.T) {
+	var order []int
+	selector := NewShuffleSelector(recordingTasks(5, Failure, &order))
+	tree := NewBehaviorTree[int](selector)
+	tree.SetRand(rand.New(rand.NewSource(1)))
+
+	orders := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		order = nil
+		if status := tree.Tick(0); status != Failure {
+			t.Fatalf("Expected Failure, but got %v", status)
+		}
+		if !isPermutation(order, 5) {
+			t.Fatalf("Expected every child to be tried once, but got %v", order)
+		}
+		orders[fmt.Sprint(order)] = true
+	}
+	if len(orders) < 2 {
+		t.Error("Expected the children to be shuffled on every start")
+	}
+}
+
+func TestShuffleSelector_SucceedsAndResumes(t *testing.T) {
+	running := newHaltableTask(Running, Success)
+	selector := NewShuffleSelector([]Node[int]{statusTask(Failure), running, statusTask(Failure)})
+	tree := NewBehaviorTree[int](selector)
+
+	statuses := []Status{tree.Tick(0)}
+	order := append([]int(nil), selector.Order...)
+	selector.Start(0)
+	statuses = append(statuses, tree.Tick(0))
+	if !reflect.DeepEqual(statuses, []Status{Running, Success}) || running.started != 1 || !reflect.DeepEqual(selector.Order, order) {
+		t.Errorf("Expected the selector to resume at the running child, got %v and %d starts", statuses, running.started)
+	}
+
+	if status := TickNode[int](NewShuffleSelector[int](nil), 0); status != Failure {
+		t.Errorf("Expected an empty selector to fail, but got %v", status)
+	}
+}
+
+func TestShuffleSequence_RunsEveryChild(t *testing.T) {
+	var order []int
+	sequence := NewShuffleSequence(recordingTasks(5, Success, &order))
+	tree := NewBehaviorTree[int](sequence)
+	tree.SetRand(rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 5; i++ {
+		order = nil
+		if status := tree.Tick(0); status != Success {
+			t.Fatalf("Expected Success, but got %v", status)
+		}
+		if !isPermutation(order, 5) {
+			t.Fatalf("Expected every child to run once, but got %v", order)
+		}
+	}
+
+	failing := NewShuffleSequence([]Node[int]{statusTask(Success), statusTask(Failure)})
+	if status := TickNode[int](failing, 0); status != Failure {
+		t.Errorf("Expected the sequence to fail with a failing child, but got %v", status)
+	}
+	if status := TickNode[int](NewShuffleSequence[int](nil), 0); status != Success {
+		t.Errorf("Expected an empty sequence to succeed, but got %v", status)
+	}
+}
+
+func TestShuffleSequence_Resumes(t *testing.T) {
+	running := newHaltableTask(Running, Success)
+	sequence := NewShuffleSequence([]Node[int]{statusTask(Success), running, statusTask(Success)})
+	tree := NewBehaviorTree[int](sequence)
+
+	status := tree.Tick(0)
+	for status == Running {
+		sequence.Start(0)
+		status = tree.Tick(0)
+	}
+	if status != Success || running.started != 1 || running.runs != 2 {
+		t.Errorf("Expected the sequence to resume at the running child, got %v and %d starts", status, running.started)
+	}
+}
+
+func TestShuffle_WithoutControl(t *testing.T) {
+	selector := NewShuffleSelector([]Node[int]{statusTask(Success)})
+	selector.Start(0)
+	selector.Run(0)
+	selector.Fail()
+
+	sequence := NewShuffleSequence([]Node[int]{statusTask(Failure)})
+	sequence.Start(0)
+	sequence.Run(0)
+	sequence.Success()
+	if selector.NodeRunning || sequence.NodeRunning {
+		t.Error("Expected the nodes to run without a control node")
+	}
+}
+
+func TestShuffle_Halt(t *testing.T) {
+	selectorChild, sequenceChild := newHaltableTask(Running), newHaltableTask(Running)
+	selector := NewShuffleSelector([]Node[int]{selectorChild})
+	sequence := NewShuffleSequence([]Node[int]{sequenceChild})
+	tree := NewBehaviorTree[int](NewParallel([]Node[int]{selector, sequence}, RequireAll, RequireOne))
+	tree.AddListener(ListenerFunc[int](func(event Event[int]) {}))
+
+	tree.Tick(0)
+	if selector.Blackboard() != tree.Blackboard() || sequence.Blackboard() != tree.Blackboard() {
+		t.Error("Expected the nodes to share the blackboard of the tree")
+	}
+	tree.Halt(0)
+	selector.Halt(0)
+	sequence.Halt(0)
+	if selectorChild.halted != 1 || sequenceChild.halted != 1 || selector.NodeRunning || sequence.NodeRunning {
+		t.Errorf("Expected the running children to be halted once, got %d and %d", selectorChild.halted, sequenceChild.halted)
+	}
+	if selector.Kind() != KindComposite || sequence.Kind() != KindComposite {
+		t.Error("Expected composite nodes")
+	}
+}
+
+func TestRegistry_Shuffle(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><ShuffleSequence><Succeed/><ShuffleSelector><Fail/><Succeed/></ShuffleSelector></ShuffleSequence></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	definition, err := registry.Describe(NewBehaviorTree[int](NewShuffleSequence([]Node[int]{NewShuffleSelector[int](nil)})))
+	if err != nil || definition.Type != "ShuffleSequence" || definition.Children[0].Type != "ShuffleSelector" {
+		t.Errorf("Expected the shuffling nodes to be described, but got %+v (%v)", definition, err)
+	}
+}
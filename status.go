@@ -0,0 +1,102 @@
+package behaviortree
+
+// Status represents the outcome a node reports for a single tick.
+type Status int
+
+const (
+	// Invalid indicates that the node has not reported an outcome.
+	Invalid Status = iota
+	// Success indicates that the node has completed successfully.
+	Success
+	// Failure indicates that the node has failed.
+	Failure
+	// Running indicates that the node is still in progress and should be run again on the next tick.
+	Running
+)
+
+// String returns the name of the status.
+func (s Status) String() string {
+	switch s {
+	case Success:
+		return "Success"
+	case Failure:
+		return "Failure"
+	case Running:
+		return "Running"
+	default:
+		return "Invalid"
+	}
+}
+
+// TickNode runs a single tick of the given node and returns the status it reported. The node's control
+// is replaced by a recorder, so any node implementing the callback-based Node interface can be driven
+// this way. The node is finished once it reports Success or Failure.
+func TickNode[T any](node Node[T], object T) Status {
+	recorder := &statusRecorder[T]{}
+	node.SetControl(recorder)
+	node.Start(object)
+	node.Run(object)
+	if recorder.Status == Success || recorder.Status == Failure {
+		node.Finish(object)
+	}
+	return recorder.Status
+}
+
+// report signals the given status to the control node using the callback-based API.
+// Invalid is not signalled.
+func report[T any](control Node[T], status Status) {
+	if control == nil {
+		return
+	}
+	switch status {
+	case Running:
+		control.Running()
+	case Success:
+		control.Success()
+	case Failure:
+		control.Fail()
+	}
+}
+
+// statusRecorder is a control node that remembers the last status reported by its child.
+// It adapts the callback-based Node interface to the status-returning API.
+type statusRecorder[T any] struct {
+	Status Status  // The last status reported by the child node.
+	Parent Node[T] // The node the recorder reports for, used to reach its blackboard.
+}
+
+// SetControl is a no-op; the recorder is always at the top of the chain it observes.
+func (r *statusRecorder[T]) SetControl(control Node[T]) {
+}
+
+// Start is a no-op; the recorder has no execution of its own.
+func (r *statusRecorder[T]) Start(object T) {
+}
+
+// Finish is a no-op; the recorder has no execution of its own.
+func (r *statusRecorder[T]) Finish(object T) {
+}
+
+// Run is a no-op; the recorder has no execution of its own.
+func (r *statusRecorder[T]) Run(object T) {
+}
+
+// Running records that the child node is still in progress.
+func (r *statusRecorder[T]) Running() {
+	r.Status = Running
+}
+
+// Success records that the child node has succeeded.
+func (r *statusRecorder[T]) Success() {
+	r.Status = Success
+}
+
+// Fail records that the child node has failed.
+func (r *statusRecorder[T]) Fail() {
+	r.Status = Failure
+}
+
+// Blackboard returns the blackboard provided by the parent node, or nil if there is none.
+func (r *statusRecorder[T]) Blackboard() *Blackboard {
+	return blackboardOf(r.Parent)
+}
@@ -0,0 +1,141 @@
+package behaviortree
+
+import "testing"
+
+func TestStatus_String(t *testing.T) {
+	cases := map[Status]string{
+		Invalid:    "Invalid",
+		Success:    "Success",
+		Failure:    "Failure",
+		Running:    "Running",
+		Status(42): "Invalid",
+	}
+	for status, expected := range cases {
+		if status.String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, status.String())
+		}
+	}
+}
+
+func TestTickNode_ReportsStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		run    func(task *Task[int], obj int)
+		status Status
+	}{
+		{"success", func(task *Task[int], obj int) { task.Success() }, Success},
+		{"failure", func(task *Task[int], obj int) { task.Fail() }, Failure},
+		{"running", func(task *Task[int], obj int) { task.Running() }, Running},
+		{"silent", func(task *Task[int], obj int) {}, Invalid},
+	}
+	for _, c := range cases {
+		if status := TickNode[int](NewTask(c.run), 0); status != c.status {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.status, status)
+		}
+	}
+}
+
+func TestTickNode_FinishesCompletedNode(t *testing.T) {
+	mockNode := NewMockNode[int](t)
+
+	if status := TickNode[int](mockNode, 42); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if !mockNode.StartCalled || !mockNode.RunCalled || !mockNode.FinishCalled {
+		t.Error("Expected Start, Run and Finish to be called on the node")
+	}
+}
+
+func TestTickNode_Composites(t *testing.T) {
+	succeed := func(task *Task[int], obj int) { task.Success() }
+	fail := func(task *Task[int], obj int) { task.Fail() }
+
+	sequence := NewSequence([]Node[int]{NewTask(succeed), NewTask(fail)})
+	if status := TickNode[int](sequence, 0); status != Failure {
+		t.Errorf("Expected Sequence to report Failure, but got %v", status)
+	}
+
+	priority := NewPriority([]Node[int]{NewTask(fail), NewTask(succeed)})
+	if status := TickNode[int](priority, 0); status != Success {
+		t.Errorf("Expected Priority to report Success, but got %v", status)
+	}
+
+	random := NewRandom([]Node[int]{NewTask(succeed)})
+	if status := TickNode[int](random, 0); status != Success {
+		t.Errorf("Expected Random to report Success, but got %v", status)
+	}
+
+	invert := NewInvertDecorator[int](NewTask(succeed))
+	if status := TickNode[int](invert, 0); status != Failure {
+		t.Errorf("Expected InvertDecorator to report Failure, but got %v", status)
+	}
+}
+
+func TestBehaviorTree_Tick(t *testing.T) {
+	count := 0
+	task := NewTask(func(task *Task[int], obj int) {
+		count++
+		if count < 2 {
+			task.Running()
+		} else {
+			task.Success()
+		}
+	})
+	tree := NewBehaviorTree[int](task)
+
+	if status := tree.Tick(0); status != Running {
+		t.Errorf("Expected first tick to report Running, but got %v", status)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected second tick to report Success, but got %v", status)
+	}
+	if tree.LastStatus != Success {
+		t.Errorf("Expected LastStatus to be Success, but got %v", tree.LastStatus)
+	}
+}
+
+func TestBehaviorTree_TickFailAndInvalid(t *testing.T) {
+	tree := NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) { task.Fail() }))
+	if status := tree.Tick(0); status != Failure {
+		t.Errorf("Expected Failure, but got %v", status)
+	}
+
+	tree = NewBehaviorTree[int](NewTask(func(task *Task[int], obj int) {}))
+	if status := tree.Tick(0); status != Invalid {
+		t.Errorf("Expected Invalid, but got %v", status)
+	}
+}
+
+func TestBaseNode_Report(t *testing.T) {
+	control := &statusRecorder[int]{}
+	task := NewTask(func(task *Task[int], obj int) { task.Report(Running) })
+	task.SetControl(control)
+
+	for _, status := range []Status{Running, Success, Failure} {
+		task.Report(status)
+		if control.Status != status {
+			t.Errorf("Expected control to record %v, but got %v", status, control.Status)
+		}
+	}
+
+	control.Status = Success
+	task.Report(Invalid)
+	if control.Status != Success {
+		t.Error("Expected Invalid to not be signalled")
+	}
+
+	task.SetControl(nil)
+	task.Report(Success)
+}
+
+func TestStatusRecorder_NoOps(t *testing.T) {
+	recorder := &statusRecorder[int]{}
+	recorder.SetControl(nil)
+	recorder.Start(0)
+	recorder.Run(0)
+	recorder.Finish(0)
+
+	if recorder.Status != Invalid {
+		t.Errorf("Expected recorder to stay Invalid, but got %v", recorder.Status)
+	}
+}
@@ -0,0 +1,288 @@
+package behaviortree
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// Library holds named trees that other trees include with SubTree nodes, so that a behavior used by many
+// trees is built once. The trees of a library are templates: they are never ticked themselves, and every
+// SubTree node runs its own instance created with Clone. Library is safe for concurrent use.
+type Library[T any] struct {
+	mu    sync.RWMutex
+	trees map[string]Node[T] // The templates by name.
+}
+
+// NewLibrary creates a new, empty Library.
+func NewLibrary[T any]() *Library[T] {
+	return &Library[T]{
+		trees: make(map[string]Node[T]),
+	}
+}
+
+// Add adds the tree rooted at root under the given name, replacing any previous tree with that name.
+// The root can be a *BehaviorTree, such as a tree loaded by a Registry. SubTree nodes that have already
+// instantiated the previous tree keep their instances.
+func (l *Library[T]) Add(name string, root Node[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.trees[name] = root
+}
+
+// Names returns the names of the trees of the library in sorted order.
+func (l *Library[T]) Names() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	names := make([]string, 0, len(l.trees))
+	for name := range l.trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Instantiate returns a new instance of the tree with the given name, created with Clone.
+func (l *Library[T]) Instantiate(name string) (Node[T], error) {
+	l.mu.RLock()
+	root, ok := l.trees[name]
+	l.mu.RUnlock()
+	if !ok || root == nil {
+		return nil, fmt.Errorf("behaviortree: unknown tree %q", name)
+	}
+	return Clone(root)
+}
+
+// SubTree is a node that runs an instance of a tree of a Library. The instance is created when the node
+// first runs, so a SubTree can be placed in any number of trees, and the trees of a library can include
+// each other, even recursively as long as the recursion ends at run time. If the tree cannot be
+// instantiated, the node fails and keeps the error in Err.
+//
+// If Remap is nil, the instance shares the blackboard visible to the SubTree node. Otherwise the instance
+// gets a blackboard of its own and only the remapped entries are exchanged: each time the instance starts,
+// the enclosing entries named by Remap are copied into its blackboard, and while it runs, its writes to
+// those keys are written through to the enclosing blackboard.
+//
+// SetRand applies to the instance like to the rest of the tree, including an instance created later:
+// the node keeps the source and passes it on when the instance is created, so that runs through a
+// SubTree can be replayed from a seed.
+type SubTree[T any] struct {
+	Decorator[T] // Embeds the Decorator structure; the child node is the instance, once created.
+
+	Library *Library[T]       // The library holding the referenced tree.
+	Tree    string            // The name of the referenced tree.
+	Remap   map[string]string // Maps the keys of the instance to the keys of the enclosing blackboard, or nil to share it.
+	Rand    *rand.Rand        // The source passed on to the instance when it is created, or nil to keep its own sources.
+
+	NodeRunning bool  // Indicates whether the instance has been started and has not completed yet.
+	Err         error // The error of the last failed attempt to instantiate the tree, or nil.
+
+	blackboard *Blackboard // The blackboard of the instance when Remap is set.
+	copying    bool        // Indicates whether the remapped entries are being copied into the instance.
+}
+
+// NewSubTree creates a new SubTree running the tree of the library with the given name. Remap maps the
+// blackboard keys used by the tree to the keys of the enclosing blackboard, or is nil to share it.
+func NewSubTree[T any](library *Library[T], tree string, remap map[string]string) *SubTree[T] {
+	return &SubTree[T]{
+		Library: library,
+		Tree:    tree,
+		Remap:   remap,
+	}
+}
+
+// Instantiate creates the instance of the referenced tree unless it has been created already. Calling it
+// before the first run reports a missing tree early; otherwise the first run creates the instance.
+func (s *SubTree[T]) Instantiate() error {
+	if s.Node != nil {
+		return nil
+	}
+	if s.Library == nil {
+		s.Err = fmt.Errorf("behaviortree: no library for tree %q", s.Tree)
+		return s.Err
+	}
+	node, err := s.Library.Instantiate(s.Tree)
+	if err != nil {
+		s.Err = err
+		return err
+	}
+	s.Err = nil
+	s.Node = node
+	s.Node.SetControl(s)
+	if s.Rand != nil {
+		setRand(s.Node, s.Rand)
+	}
+	if s.ID() != "" {
+		AssignIDs(s.Node, s.ID()+"/0")
+	}
+	return nil
+}
+
+// Start initializes the node with the provided object unless the instance is running.
+func (s *SubTree[T]) Start(object T) {
+	if !s.NodeRunning {
+		s.setObject(object)
+	}
+}
+
+// Run executes the instance, creating it on the first run and starting it if it is not running. The
+// remapped entries are copied into the instance when it starts.
+func (s *SubTree[T]) Run(object T) {
+	if err := s.Instantiate(); err != nil {
+		if s.ControlNode != nil {
+			s.ControlNode.Fail()
+		}
+		return
+	}
+	if !s.NodeRunning {
+		s.NodeRunning = true
+		s.copyIn()
+		s.Node.Start(object)
+	}
+	s.Node.Run(object)
+}
+
+// Success is called when the instance succeeds. It signals success to the control node.
+func (s *SubTree[T]) Success() {
+	s.NodeRunning = false
+	s.Node.Finish(s.Object)
+	if s.ControlNode != nil {
+		s.ControlNode.Success()
+	}
+}
+
+// Fail is called when the instance fails. It signals failure to the control node.
+func (s *SubTree[T]) Fail() {
+	s.NodeRunning = false
+	s.Node.Finish(s.Object)
+	if s.ControlNode != nil {
+		s.ControlNode.Fail()
+	}
+}
+
+// Finish finalizes the instance, if it has been created.
+func (s *SubTree[T]) Finish(object T) {
+	if s.Node != nil {
+		s.Node.Finish(object)
+	}
+}
+
+// Halt interrupts the running instance.
+func (s *SubTree[T]) Halt(object T) {
+	if s.NodeRunning {
+		Halt(s.Node, object)
+	}
+	s.NodeRunning = false
+}
+
+// SetRand sets the source of the random choices of the instance, including an instance created later.
+// The nodes of an existing instance are reached by BehaviorTree.SetRand as children of the node.
+func (s *SubTree[T]) SetRand(random *rand.Rand) {
+	s.Rand = random
+}
+
+// Children returns the instance, or nothing if it has not been created yet.
+func (s *SubTree[T]) Children() []Node[T] {
+	if s.Node == nil {
+		return nil
+	}
+	return s.Decorator.Children()
+}
+
+// Kind reports that the SubTree node is a nested tree.
+func (s *SubTree[T]) Kind() Kind {
+	return KindTree
+}
+
+// Blackboard returns the blackboard of the instance: the enclosing blackboard if Remap is nil, or the
+// blackboard of its own otherwise, created on first use.
+func (s *SubTree[T]) Blackboard() *Blackboard {
+	if s.Remap == nil {
+		return s.Decorator.Blackboard()
+	}
+	if s.blackboard == nil {
+		s.blackboard = NewBlackboard()
+		s.blackboard.Subscribe(s.writeThrough)
+	}
+	return s.blackboard
+}
+
+// copyIn copies the remapped entries of the enclosing blackboard into the blackboard of the instance.
+// Entries missing from the enclosing blackboard are deleted.
+func (s *SubTree[T]) copyIn() {
+	if s.Remap == nil {
+		return
+	}
+	enclosing, own := s.Decorator.Blackboard(), s.Blackboard()
+	s.copying = true
+	defer func() { s.copying = false }()
+	for key, enclosingKey := range s.Remap {
+		var value any
+		ok := false
+		if enclosing != nil {
+			value, ok = enclosing.Get(enclosingKey)
+		}
+		if ok {
+			own.Set(key, value)
+		} else {
+			own.Delete(key)
+		}
+	}
+}
+
+// writeThrough writes a change of a remapped entry made by the instance to the enclosing blackboard.
+func (s *SubTree[T]) writeThrough(change Change) {
+	enclosingKey, ok := s.Remap[change.Key]
+	enclosing := s.Decorator.Blackboard()
+	if s.copying || !ok || enclosing == nil {
+		return
+	}
+	if change.Deleted {
+		enclosing.Delete(enclosingKey)
+	} else {
+		enclosing.Set(enclosingKey, change.New)
+	}
+}
+
+// RegisterLibrary registers the SubTree node type, which builds SubTree nodes running the trees of the
+// library. The param "tree" names the tree, and every other param remaps a blackboard key of the tree to
+// an enclosing key written in braces, as in BehaviorTree.CPP:
+//
+//	{"type": "SubTree", "params": {"tree": "Recharge", "target": "{charger}"}}
+func (r *Registry[T]) RegisterLibrary(library *Library[T]) {
+	r.Register("SubTree", func(params Params, children []Node[T]) (Node[T], error) {
+		if err := expectChildren(children, 0); err != nil {
+			return nil, err
+		}
+		tree, err := params.String("tree", "")
+		if err != nil {
+			return nil, err
+		}
+		if tree == "" {
+			return nil, &ParamError{Name: "tree", Err: errors.New("missing tree name")}
+		}
+		names := make([]string, 0, len(params))
+		for name := range params {
+			if name != "tree" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		var remap map[string]string
+		for _, name := range names {
+			value := params[name]
+			key, ok := value.(string)
+			if !ok || len(key) < 3 || key[0] != '{' || key[len(key)-1] != '}' {
+				return nil, params.invalid(name, "a blackboard key in braces", value)
+			}
+			if remap == nil {
+				remap = make(map[string]string)
+			}
+			remap[name] = key[1 : len(key)-1]
+		}
+		return NewSubTree(library, tree, remap), nil
+	})
+}
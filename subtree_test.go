@@ -0,0 +1,332 @@
+package behaviortree
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rechargeLibrary returns a library with a "Recharge" tree that runs while obj is positive and
+// otherwise writes "charged" to the "battery" entry and succeeds.
+func rechargeLibrary() *Library[int] {
+	library := NewLibrary[int]()
+	library.Add("Recharge", NewTimeoutDecorator[int](NewTask(func(task *Task[int], obj int) {
+		if obj > 0 {
+			task.Running()
+			return
+		}
+		task.Blackboard().Set("battery", "charged")
+		task.Success()
+	}), time.Hour))
+	return library
+}
+
+func TestSubTree_Instances(t *testing.T) {
+	library := rechargeLibrary()
+	first := NewSubTree(library, "Recharge", nil)
+	second := NewSubTree(library, "Recharge", nil)
+	firstTree := NewBehaviorTree[int](NewSequence([]Node[int]{first}))
+	secondTree := NewBehaviorTree[int](NewSequence([]Node[int]{second}))
+
+	if len(first.Children()) != 0 || first.Kind() != KindTree {
+		t.Error("Expected a SubTree without an instance before the first run")
+	}
+	first.Finish(0)
+	if status := firstTree.Tick(1); status != Running {
+		t.Errorf("Expected the first tree to be running, but got %v", status)
+	}
+	if status := secondTree.Tick(0); status != Success {
+		t.Errorf("Expected the second tree to succeed, but got %v", status)
+	}
+	if first.Node == nil || first.Node == second.Node {
+		t.Error("Expected every SubTree to run its own instance")
+	}
+	if IDOf(first.Node) != "root/0/0" {
+		t.Errorf("Expected the instance to be assigned IDs, but got %q", IDOf(first.Node))
+	}
+	if value, _ := secondTree.Blackboard().Get("battery"); value != "charged" {
+		t.Errorf("Expected the instance to share the enclosing blackboard, but got %v", value)
+	}
+
+	first.Halt(1)
+	if first.NodeRunning || first.Node.(*TimeoutDecorator[int]).NodeRunning {
+		t.Error("Expected the instance to be halted")
+	}
+	if status := firstTree.Tick(0); status != Success {
+		t.Errorf("Expected the first tree to restart its instance, but got %v", status)
+	}
+	if !reflect.DeepEqual(library.Names(), []string{"Recharge"}) {
+		t.Errorf("Unexpected library names %v", library.Names())
+	}
+}
+
+func TestSubTree_Remap(t *testing.T) {
+	library := NewLibrary[int]()
+	var seen []any
+	library.Add("Chase", NewTask(func(task *Task[int], obj int) {
+		target, _ := task.Blackboard().Get("target")
+		_, leaked := task.Blackboard().Get("secret")
+		seen = append(seen, target, leaked)
+		task.Blackboard().Set("result", "caught")
+		task.Blackboard().Set("scratch", 1)
+		task.Blackboard().Delete("target")
+		task.Success()
+	}))
+	sub := NewSubTree(library, "Chase", map[string]string{"target": "enemy", "result": "outcome"})
+	tree := NewBehaviorTree[int](sub)
+	tree.Blackboard().Set("enemy", "wolf")
+	tree.Blackboard().Set("secret", true)
+
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if value, _ := tree.Blackboard().Get("outcome"); value != "caught" {
+		t.Errorf("Expected the remapped write to reach the enclosing blackboard, but got %v", value)
+	}
+	if _, ok := tree.Blackboard().Get("enemy"); ok {
+		t.Error("Expected the remapped delete to reach the enclosing blackboard")
+	}
+	if _, ok := tree.Blackboard().Get("scratch"); ok {
+		t.Error("Expected entries that are not remapped to stay in the instance")
+	}
+
+	tree.Tick(0)
+	if expected := []any{"wolf", false, nil, false}; !reflect.DeepEqual(seen, expected) {
+		t.Errorf("Expected the instance to see %v, but got %v", expected, seen)
+	}
+
+	orphan := NewSubTree(library, "Chase", map[string]string{"target": "enemy"})
+	orphan.Run(0)
+	if _, ok := orphan.Blackboard().Get("result"); !ok {
+		t.Error("Expected a SubTree without an enclosing blackboard to run on its own")
+	}
+}
+
+func TestSubTree_Errors(t *testing.T) {
+	library := NewLibrary[int]()
+	sub := NewSubTree(library, "Later", nil)
+	tree := NewBehaviorTree[int](sub)
+	if status := tree.Tick(0); status != Failure || sub.Err == nil {
+		t.Errorf("Expected an unknown tree to fail, but got %v, %v", status, sub.Err)
+	}
+	sub.Run(0)
+
+	library.Add("Later", statusTask(Success))
+	if status := tree.Tick(0); status != Success || sub.Err != nil {
+		t.Errorf("Expected the tree to be instantiated once added, but got %v, %v", status, sub.Err)
+	}
+
+	library.Add("Failing", statusTask(Failure))
+	if status := NewBehaviorTree[int](NewSubTree(library, "Failing", nil)).Tick(0); status != Failure {
+		t.Errorf("Expected the failure of the instance to be reported, but got %v", status)
+	}
+	if err := NewSubTree[int](nil, "Any", nil).Instantiate(); err == nil {
+		t.Error("Expected an error without a library")
+	}
+	library.Add("Broken", &MockNode[int]{})
+	if err := NewSubTree(library, "Broken", nil).Instantiate(); err == nil {
+		t.Error("Expected an error for a tree that cannot be cloned")
+	}
+}
+
+func TestSubTree_Recursive(t *testing.T) {
+	library := NewLibrary[int]()
+	remaining := NewKey[int]("remaining")
+	library.Add("Countdown", NewPriority([]Node[int]{
+		NewTask(func(task *Task[int], obj int) {
+			if n, _ := remaining.Get(task.Blackboard()); n == 0 {
+				task.Success()
+			} else {
+				task.Fail()
+			}
+		}),
+		NewSequence([]Node[int]{
+			NewTask(func(task *Task[int], obj int) {
+				n, _ := remaining.Get(task.Blackboard())
+				remaining.Set(task.Blackboard(), n-1)
+				task.Success()
+			}),
+			NewSubTree(library, "Countdown", nil),
+		}),
+	}))
+	tree := NewBehaviorTree[int](NewSubTree(library, "Countdown", nil))
+	remaining.Set(tree.Blackboard(), 3)
+
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected the recursion to end, but got %v", status)
+	}
+	depth := 0
+	Walk[int](tree, func(node Node[int], d int) bool {
+		if _, ok := node.(*SubTree[int]); ok {
+			depth++
+		}
+		return true
+	})
+	if depth != 5 {
+		t.Errorf("Expected 4 nested instances and 1 uninstantiated SubTree, but got %d SubTree nodes", depth)
+	}
+}
+
+func TestSubTree_Clone(t *testing.T) {
+	library := rechargeLibrary()
+	template := NewBehaviorTree[int](NewSubTree(library, "Recharge", map[string]string{"battery": "charge"}))
+	template.Tick(1)
+
+	instance, err := template.NewInstance()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	original, clone := template.RootNode.(*SubTree[int]), instance.RootNode.(*SubTree[int])
+	if clone.Node == nil || clone.Node == original.Node || clone.NodeRunning {
+		t.Error("Expected the clone to get its own fresh instance")
+	}
+	if status := instance.Tick(0); status != Success {
+		t.Errorf("Expected the clone to run, but got %v", status)
+	}
+	if value, _ := instance.Blackboard().Get("charge"); value != "charged" {
+		t.Errorf("Expected the clone to keep the remapping, but got %v", value)
+	}
+	if _, ok := template.Blackboard().Get("charge"); ok {
+		t.Error("Expected the template to be unaffected")
+	}
+}
+
+func TestSubTree_Replay(t *testing.T) {
+	var picks []int
+	library := NewLibrary[int]()
+	library.Add("Pick", NewRandom(recordingTasks(4, Success, &picks)))
+	library.Add("Nested", NewSequence([]Node[int]{
+		NewShuffleSequence(recordingTasks(3, Success, &picks)),
+		NewSubTree(library, "Pick", nil),
+	}))
+	run := func(seed int64) []int {
+		picks = nil
+		tree := NewBehaviorTree[int](NewSubTree(library, "Nested", nil))
+		tree.SetRand(rand.New(rand.NewSource(seed)))
+		for i := 0; i < 20; i++ {
+			tree.Tick(0)
+		}
+		return picks
+	}
+
+	first, second := run(1), run(1)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected runs through a SubTree to be replayed from the seed, but got %v and %v", first, second)
+	}
+	if reflect.DeepEqual(first, run(2)) {
+		t.Error("Expected another seed to make other choices")
+	}
+}
+
+func TestSubTree_Pool(t *testing.T) {
+	library := rechargeLibrary()
+	trees := make([]*BehaviorTree[int], 16)
+	objects := make([]int, len(trees))
+	for i := range trees {
+		trees[i] = NewBehaviorTree[int](NewSubTree(library, "Recharge", nil))
+		objects[i] = i % 2
+	}
+	statuses, err := NewPool[int](4).Tick(trees, objects)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, status := range statuses {
+		if expected := []Status{Success, Running}[i%2]; status != expected {
+			t.Errorf("Expected tree %d to report %v, but got %v", i, expected, status)
+		}
+	}
+}
+
+func TestRegistry_RegisterLibrary(t *testing.T) {
+	registry := newTestRegistry()
+	library := NewLibrary[int]()
+	library.Add("Recharge", NewSequence([]Node[int]{NewTask(func(task *Task[int], obj int) {
+		task.Blackboard().Set("charger", "dock")
+		task.Success()
+	})}))
+	registry.RegisterLibrary(library)
+
+	tree, err := registry.LoadJSON([]byte(`{"type": "Sequence", "children": [
+		{"type": "SubTree", "name": "charge", "params": {"tree": "Recharge", "charger": "{station}"}},
+		{"type": "Succeed"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	if value, _ := tree.Blackboard().Get("station"); value != "dock" {
+		t.Errorf("Expected the remapped entry to be written, but got %v", value)
+	}
+
+	definition, err := registry.Describe(tree)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := Definition{Type: "SubTree", Name: "charge", Params: Params{"tree": "Recharge", "charger": "{station}"}}
+	if !reflect.DeepEqual(definition.Children[0], expected) {
+		t.Errorf("Expected the SubTree to be described by its reference, but got %+v", definition.Children[0])
+	}
+	definition, err = registry.Describe(NewSubTree(library, "Recharge", map[string]string{"charger": "station"}))
+	if err != nil || !reflect.DeepEqual(definition, Definition{Type: "SubTree", Params: Params{"tree": "Recharge", "charger": "{station}"}}) {
+		t.Errorf("Unexpected description %+v, %v", definition, err)
+	}
+
+	cases := []struct {
+		definition Definition
+		path       string
+	}{
+		{Definition{Type: "SubTree"}, "$.params.tree"},
+		{Definition{Type: "SubTree", Params: Params{"tree": 1}}, "$.params.tree"},
+		{Definition{Type: "SubTree", Params: Params{"tree": "Recharge", "a": "{a}", "b": "b"}}, "$.params.b"},
+		{Definition{Type: "SubTree", Params: Params{"tree": "Recharge"}, Children: []Definition{{Type: "Succeed"}}}, "$.children"},
+	}
+	for _, c := range cases {
+		_, err := registry.Build(c.definition)
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) || loadErr.Path != c.path {
+			t.Errorf("%+v: expected a LoadError at %q, but got %v", c.definition, c.path, err)
+		}
+	}
+}
+
+func TestRegistry_LoadXMLLibrary(t *testing.T) {
+	registry := newTestRegistry()
+	library := NewLibrary[int]()
+	library.Add("Recharge", statusTask(Success))
+	registry.RegisterLibrary(library)
+
+	document := `<root main_tree_to_execute="Main"><BehaviorTree ID="Main"><Sequence><SubTree ID="Recharge" charger="{station}"/><SubTree ID="Local"/></Sequence></BehaviorTree>` +
+		`<BehaviorTree ID="Local"><Succeed/></BehaviorTree></root>`
+	definition, err := ParseXML([]byte(document))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := Definition{Type: "Sequence", Children: []Definition{
+		{Type: "SubTree", Params: Params{"tree": "Recharge", "charger": "{station}"}},
+		{Type: "Succeed"},
+	}}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("Unexpected definition %+v", definition)
+	}
+
+	tree, err := registry.LoadXML([]byte(document))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected Success, but got %v", status)
+	}
+	var b bytes.Buffer
+	if err := registry.WriteXML(&b, tree); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), `<SubTree charger="{station}" ID="Recharge"></SubTree>`) {
+		t.Errorf("Expected the SubTree to be written as a reference, but got:\n%s", b.String())
+	}
+}
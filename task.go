@@ -26,4 +26,9 @@ func (t *Task[T]) Run(object T) {
 		t.RunFunc(t, object)
 		t.RunCalled = true
 	}
-}
\ No newline at end of file
+}
+
+// Kind reports that the task is an action node.
+func (t *Task[T]) Kind() Kind {
+	return KindAction
+}
@@ -0,0 +1,79 @@
+package behaviortree
+
+import "time"
+
+// TimeoutDecorator is a decorator node that fails if its child node has not completed within a duration.
+// The time is measured with Clock from the tick that starts the child node. When the deadline has passed,
+// the decorator halts the running child node at the beginning of the next tick and fails, so a child that
+// reports Running forever cannot hang the branch.
+type TimeoutDecorator[T any] struct {
+	Decorator[T] // Embeds the Decorator structure to wrap a single child node.
+
+	Timeout time.Duration // The time the child node is given to complete.
+	Clock   Clock         // Measures the time. If nil, the system clock is used.
+
+	Deadline    time.Time // The time at which the running child node times out.
+	NodeRunning bool      // Indicates whether the child node has been started and has not completed yet.
+}
+
+// NewTimeoutDecorator creates a new TimeoutDecorator that gives the specified child node the given time to complete.
+func NewTimeoutDecorator[T any](node Node[T], timeout time.Duration) *TimeoutDecorator[T] {
+	decorator := &TimeoutDecorator[T]{
+		Timeout: timeout,
+	}
+	decorator.Node = node
+	decorator.Node.SetControl(decorator)
+	return decorator
+}
+
+// Start initializes the decorator with the provided object unless the child node is running.
+func (d *TimeoutDecorator[T]) Start(object T) {
+	if !d.NodeRunning {
+		d.setObject(object)
+	}
+}
+
+// Run executes the child node, starting it and its timer first if it is not running. If the running
+// child node has timed out, it is halted and the decorator fails instead.
+func (d *TimeoutDecorator[T]) Run(object T) {
+	now := clockOrSystem(d.Clock).Now()
+	if !d.NodeRunning {
+		d.NodeRunning = true
+		d.Deadline = now.Add(d.Timeout)
+		d.Node.Start(object)
+	} else if !now.Before(d.Deadline) {
+		d.NodeRunning = false
+		Halt(d.Node, object)
+		if d.ControlNode != nil {
+			d.ControlNode.Fail()
+		}
+		return
+	}
+	d.Node.Run(object)
+}
+
+// Success is called when the child node succeeds in time. It signals success to the control node.
+func (d *TimeoutDecorator[T]) Success() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Success()
+	}
+}
+
+// Fail is called when the child node fails in time. It signals failure to the control node.
+func (d *TimeoutDecorator[T]) Fail() {
+	d.NodeRunning = false
+	d.Node.Finish(d.Object)
+	if d.ControlNode != nil {
+		d.ControlNode.Fail()
+	}
+}
+
+// Halt interrupts the running child node and stops its timer.
+func (d *TimeoutDecorator[T]) Halt(object T) {
+	if d.NodeRunning {
+		Halt(d.Node, object)
+	}
+	d.NodeRunning = false
+}
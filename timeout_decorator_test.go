@@ -0,0 +1,125 @@
+package behaviortree
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeoutDecorator_TimesOut(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	child := newHaltableTask(Running)
+	timeout := NewTimeoutDecorator[int](child, time.Second)
+	timeout.Clock = clock
+	tree := NewBehaviorTree[int](timeout)
+
+	statuses := []Status{tree.Tick(0)}
+	clock.Advance(999 * time.Millisecond)
+	statuses = append(statuses, tree.Tick(0))
+	clock.Advance(time.Millisecond)
+	statuses = append(statuses, tree.Tick(0), tree.Tick(0))
+
+	expected := []Status{Running, Running, Failure, Running}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Expected %v, but got %v", expected, statuses)
+	}
+	if child.halted != 1 || child.started != 2 || child.runs != 3 {
+		t.Errorf("Expected the child to be halted once and restarted, got %d halts, %d starts and %d runs", child.halted, child.started, child.runs)
+	}
+}
+
+func TestTimeoutDecorator_CompletesInTime(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	for _, status := range []Status{Success, Failure} {
+		timeout := NewTimeoutDecorator[int](statusTask(Running, status), time.Second)
+		timeout.Clock = clock
+		tree := NewBehaviorTree[int](timeout)
+
+		tree.Tick(0)
+		clock.Advance(500 * time.Millisecond)
+		if result := tree.Tick(0); result != status {
+			t.Errorf("Expected %v, but got %v", status, result)
+		}
+		if timeout.NodeRunning {
+			t.Error("Expected the child to be done")
+		}
+	}
+}
+
+func TestTimeoutDecorator_WithoutControl(t *testing.T) {
+	timeout := NewTimeoutDecorator[int](statusTask(Running, Success, Running, Failure, Running), 0)
+	for i := 0; i < 2; i++ {
+		timeout.Start(0)
+		timeout.Run(0)
+		timeout.Run(0)
+	}
+	timeout.Run(0)
+	timeout.Run(0)
+	if timeout.NodeRunning {
+		t.Error("Expected the child to time out without a control node")
+	}
+}
+
+func TestTimeoutDecorator_Halt(t *testing.T) {
+	child := newHaltableTask(Running)
+	timeout := NewTimeoutDecorator[int](child, time.Hour)
+
+	TickNode[int](timeout, 0)
+	timeout.Halt(0)
+	timeout.Halt(0)
+	if child.halted != 1 || timeout.NodeRunning {
+		t.Errorf("Expected the running child to be halted once, but got %d", child.halted)
+	}
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><Timeout msec="250"><Run/></Timeout></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeout := tree.RootNode.(*TimeoutDecorator[int]); timeout.Timeout != 250*time.Millisecond {
+		t.Errorf("Expected a timeout of 250ms, but got %v", timeout.Timeout)
+	}
+
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, NewBehaviorTree[int](NewTimeoutDecorator[int](tree.RootNode.(*TimeoutDecorator[int]).Node, 2*time.Second))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<Timeout msec="2000">`) {
+		t.Errorf("Expected the timeout to be exported, got:\n%s", buf.String())
+	}
+
+	built, err := registry.Build(Definition{Type: "Timeout", Params: Params{"timeout": "1.5s"}, Children: []Definition{{Type: "Run"}}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeout := built.(*TimeoutDecorator[int]); timeout.Timeout != 1500*time.Millisecond {
+		t.Errorf("Expected a timeout of 1.5s, but got %v", timeout.Timeout)
+	}
+	buf.Reset()
+	if err := registry.WriteXML(&buf, NewBehaviorTree[int](built)); err != nil || !strings.Contains(buf.String(), `<Timeout msec="1500">`) {
+		t.Errorf("Expected the built timeout to be exported in milliseconds, got %v:\n%s", err, buf.String())
+	}
+
+	var loadErr *LoadError
+	_, err = registry.LoadXML([]byte(`<root><BehaviorTree><Timeout msec="1s"><Run/></Timeout></BehaviorTree></root>`))
+	if !errors.As(err, &loadErr) || !strings.HasSuffix(loadErr.Path, "/Timeout/@msec") {
+		t.Errorf("Expected an error at the msec attribute, but got %v", err)
+	}
+
+	errorCases := []Definition{
+		{Type: "Timeout"},
+		{Type: "Timeout", Params: Params{"timeout": "soon"}, Children: []Definition{{Type: "Run"}}},
+		{Type: "Timeout", Params: Params{"timeout": 250}, Children: []Definition{{Type: "Run"}}},
+	}
+	for _, definition := range errorCases {
+		if _, err := registry.Build(definition); err == nil {
+			t.Errorf("Expected an error for %+v", definition)
+		}
+	}
+}
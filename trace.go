@@ -0,0 +1,292 @@
+package behaviortree
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// EventKind identifies the lifecycle transition of a node reported by an Event.
+type EventKind int
+
+const (
+	// EventStart is emitted when a node is started.
+	EventStart EventKind = iota
+	// EventRun is emitted when a node is run.
+	EventRun
+	// EventRunning is emitted when a node signals that it is still running.
+	EventRunning
+	// EventSuccess is emitted when a node signals success.
+	EventSuccess
+	// EventFailure is emitted when a node signals failure.
+	EventFailure
+	// EventFinish is emitted when a node is finished.
+	EventFinish
+	// EventHalt is emitted when a running node is halted.
+	EventHalt
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventStart:
+		return "Start"
+	case EventRun:
+		return "Run"
+	case EventRunning:
+		return "Running"
+	case EventSuccess:
+		return "Success"
+	case EventFailure:
+		return "Failure"
+	case EventFinish:
+		return "Finish"
+	case EventHalt:
+		return "Halt"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event describes a lifecycle transition of a node in a traced behavior tree.
+type Event[T any] struct {
+	Node Node[T]   // The node that made the transition.
+	Kind EventKind // The transition.
+	Tick uint64    // The number of the tick of the tree, starting at 1.
+	Time time.Time // The time of the transition.
+}
+
+// Listener receives the events of a traced behavior tree. Listeners are called synchronously by the
+// goroutine ticking the tree.
+type Listener[T any] interface {
+	// OnEvent is called for every lifecycle transition of a node.
+	OnEvent(event Event[T])
+}
+
+// ListenerFunc adapts a function to the Listener interface.
+type ListenerFunc[T any] func(event Event[T])
+
+// OnEvent calls the function with the event.
+func (f ListenerFunc[T]) OnEvent(event Event[T]) {
+	f(event)
+}
+
+// tracer dispatches the events of the nodes of a behavior tree to its listeners.
+type tracer[T any] struct {
+	tree      *BehaviorTree[T]        // The traced tree, providing the tick number.
+	listeners []registeredListener[T] // The registered listeners in registration order.
+	nextID    int                     // The identifier of the next registered listener.
+}
+
+// registeredListener is a listener registered with a tracer.
+type registeredListener[T any] struct {
+	id       int
+	listener Listener[T]
+}
+
+// add registers the listener and returns a function removing it.
+func (t *tracer[T]) add(listener Listener[T]) func() {
+	id := t.nextID
+	t.nextID++
+	t.listeners = append(t.listeners, registeredListener[T]{id: id, listener: listener})
+	return func() {
+		for i, registered := range t.listeners {
+			if registered.id == id {
+				t.listeners = append(t.listeners[:i:i], t.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emit sends an event for the node to every listener.
+func (t *tracer[T]) emit(node Node[T], kind EventKind) {
+	if len(t.listeners) == 0 {
+		return
+	}
+	event := Event[T]{Node: node, Kind: kind, Tick: t.tree.TickCount, Time: time.Now()}
+	for _, registered := range t.listeners {
+		registered.listener.OnEvent(event)
+	}
+}
+
+// childReplacer is implemented by the built-in nodes that can have their children replaced by tracing proxies.
+type childReplacer[T any] interface {
+	replaceChild(i int, node Node[T])
+}
+
+// instrument wraps the descendants of parent in tracing proxies. Children that are already traced are
+// left as is, and the children of custom nodes that do not support replacement are not traced.
+func (t *tracer[T]) instrument(parent Node[T]) {
+	node, ok := parent.(ParentNode[T])
+	if !ok {
+		return
+	}
+	replacer, replaceable := parent.(childReplacer[T])
+	for i, child := range node.Children() {
+		if child == nil {
+			continue
+		}
+		traced, isTraced := child.(*tracedNode[T])
+		if !isTraced && replaceable {
+			traced = &tracedNode[T]{node: child, control: parent, tracer: t}
+			child.SetControl(traced)
+			replacer.replaceChild(i, traced)
+			isTraced = true
+		}
+		if isTraced {
+			child = traced.node
+		}
+		t.instrument(child)
+	}
+}
+
+// tracedNode is a transparent proxy placed between a node and its parent that reports the lifecycle
+// transitions of the node to a tracer.
+type tracedNode[T any] struct {
+	node    Node[T]    // The traced node.
+	control Node[T]    // The control node of the traced node.
+	tracer  *tracer[T] // The tracer receiving the events.
+}
+
+// SetControl sets the control node the traced node reports to.
+func (n *tracedNode[T]) SetControl(control Node[T]) {
+	n.control = control
+}
+
+// Start reports the transition and starts the traced node.
+func (n *tracedNode[T]) Start(object T) {
+	n.tracer.emit(n.node, EventStart)
+	n.node.Start(object)
+}
+
+// Run reports the transition and runs the traced node.
+func (n *tracedNode[T]) Run(object T) {
+	n.tracer.emit(n.node, EventRun)
+	n.node.Run(object)
+}
+
+// Finish reports the transition and finishes the traced node.
+func (n *tracedNode[T]) Finish(object T) {
+	n.tracer.emit(n.node, EventFinish)
+	n.node.Finish(object)
+}
+
+// Halt reports the transition and halts the traced node.
+func (n *tracedNode[T]) Halt(object T) {
+	n.tracer.emit(n.node, EventHalt)
+	Halt(n.node, object)
+}
+
+// Running reports the transition and forwards it to the control node.
+func (n *tracedNode[T]) Running() {
+	n.tracer.emit(n.node, EventRunning)
+	if n.control != nil {
+		n.control.Running()
+	}
+}
+
+// Success reports the transition and forwards it to the control node.
+func (n *tracedNode[T]) Success() {
+	n.tracer.emit(n.node, EventSuccess)
+	if n.control != nil {
+		n.control.Success()
+	}
+}
+
+// Fail reports the transition and forwards it to the control node.
+func (n *tracedNode[T]) Fail() {
+	n.tracer.emit(n.node, EventFailure)
+	if n.control != nil {
+		n.control.Fail()
+	}
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (n *tracedNode[T]) Blackboard() *Blackboard {
+	return blackboardOf(n.control)
+}
+
+// unwrap returns the node traced by a tracing proxy, or the node itself if it is not a proxy.
+func unwrap[T any](node Node[T]) Node[T] {
+	if traced, ok := node.(*tracedNode[T]); ok {
+		return traced.node
+	}
+	return node
+}
+
+// NewLogListener returns a listener writing every event to the logger, or to the standard logger if
+// logger is nil, in the form "tick 3: Bark (root/0/1): Success".
+func NewLogListener[T any](logger *log.Logger) Listener[T] {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return ListenerFunc[T](func(event Event[T]) {
+		logger.Printf("tick %d: %s: %v", event.Tick, describeEvent(event.Node), event.Kind)
+	})
+}
+
+// NewSlogListener returns a listener writing every event to the structured logger at the given level,
+// or to the default logger if logger is nil. Records carry the tick, node, id and event attributes.
+func NewSlogListener[T any](logger *slog.Logger, level slog.Level) Listener[T] {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return ListenerFunc[T](func(event Event[T]) {
+		logger.LogAttrs(context.Background(), level, "behaviortree event",
+			slog.Uint64("tick", event.Tick),
+			slog.String("node", NameOf(event.Node)),
+			slog.String("id", IDOf(event.Node)),
+			slog.String("event", event.Kind.String()),
+		)
+	})
+}
+
+// describeEvent returns the name of the node followed by its ID, if it has one.
+func describeEvent[T any](node Node[T]) string {
+	if id := IDOf(node); id != "" {
+		return NameOf(node) + " (" + id + ")"
+	}
+	return NameOf(node)
+}
+
+// RingBuffer is a listener keeping the most recent events in memory. It is safe to read the events
+// from another goroutine while the tree is ticking.
+type RingBuffer[T any] struct {
+	mu     sync.Mutex
+	events []Event[T] // The recorded events, used as a circular buffer once full.
+	next   int        // The index of the oldest event once the buffer is full.
+	full   bool       // Indicates whether the buffer has wrapped around.
+}
+
+// NewRingBuffer creates a new RingBuffer keeping the given number of most recent events.
+func NewRingBuffer[T any](size int) *RingBuffer[T] {
+	if size < 1 {
+		size = 1
+	}
+	return &RingBuffer[T]{events: make([]Event[T], 0, size)}
+}
+
+// OnEvent records the event, dropping the oldest one if the buffer is full.
+func (b *RingBuffer[T]) OnEvent(event Event[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.full {
+		b.events = append(b.events, event)
+		b.full = len(b.events) == cap(b.events)
+		return
+	}
+	b.events[b.next] = event
+	b.next = (b.next + 1) % len(b.events)
+}
+
+// Events returns the recorded events from the oldest to the most recent.
+func (b *RingBuffer[T]) Events() []Event[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	events := make([]Event[T], 0, len(b.events))
+	events = append(events, b.events[b.next:]...)
+	return append(events, b.events[:b.next]...)
+}
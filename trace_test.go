@@ -0,0 +1,252 @@
+package behaviortree
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// eventNames returns the events as "name:kind" strings.
+func eventNames(events []Event[int]) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, NameOf(event.Node)+":"+event.Kind.String())
+	}
+	return names
+}
+
+func TestEventKind_String(t *testing.T) {
+	cases := map[EventKind]string{
+		EventStart:    "Start",
+		EventRun:      "Run",
+		EventRunning:  "Running",
+		EventSuccess:  "Success",
+		EventFailure:  "Failure",
+		EventFinish:   "Finish",
+		EventKind(42): "Unknown",
+	}
+	for kind, expected := range cases {
+		if kind.String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, kind.String())
+		}
+	}
+}
+
+func TestBehaviorTree_AddListener(t *testing.T) {
+	priority := Named(NewPriority([]Node[int]{Named(statusTask(Failure), "a"), Named(statusTask(Success), "b")}), "choose")
+	tree := NewBehaviorTree[int](priority)
+
+	var events []Event[int]
+	tree.AddListener(ListenerFunc[int](func(event Event[int]) {
+		events = append(events, event)
+	}))
+	if status := tree.Tick(0); status != Success {
+		t.Fatalf("Expected Success, but got %v", status)
+	}
+
+	expected := []string{
+		"choose:Start", "choose:Run",
+		"a:Start", "a:Run", "a:Failure",
+		"b:Start", "b:Run", "b:Success",
+		"choose:Success", "choose:Finish",
+	}
+	if names := eventNames(events); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected events %v, but got %v", expected, names)
+	}
+	for _, event := range events {
+		if event.Tick != 1 || event.Time.IsZero() {
+			t.Errorf("Expected event of tick 1 with a time, but got %+v", event)
+		}
+	}
+
+	tree.Tick(0)
+	if last := events[len(events)-1]; last.Tick != 2 || len(events) != 20 {
+		t.Errorf("Expected a second traced tick, but got %d events ending with %+v", len(events), last)
+	}
+}
+
+func TestBehaviorTree_RemoveListener(t *testing.T) {
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{statusTask(Success)}))
+
+	first, second := NewRingBuffer[int](10), NewRingBuffer[int](10)
+	removeFirst := tree.AddListener(first)
+	removeSecond := tree.AddListener(second)
+	removeFirst()
+	removeFirst()
+	tree.Tick(0)
+
+	if len(first.Events()) != 0 || len(second.Events()) == 0 {
+		t.Errorf("Expected only the remaining listener to receive events, got %d and %d", len(first.Events()), len(second.Events()))
+	}
+
+	removeSecond()
+	count := len(second.Events())
+	tree.Tick(0)
+	if len(second.Events()) != count {
+		t.Error("Expected no events after removing every listener")
+	}
+	if status := tree.Tick(0); status != Success {
+		t.Errorf("Expected the traced tree to keep working, but got %v", status)
+	}
+}
+
+func TestBehaviorTree_TraceStructure(t *testing.T) {
+	a, b, c := statusTask(Success), statusTask(Running), statusTask(Success)
+	inner := NewBehaviorTree[int](NewAlwaysSucceedDecorator[int](c))
+	custom := &parentNode{nodes: []Node[int]{a}}
+	sequence := NewSequence([]Node[int]{inner, NewParallel([]Node[int]{b, NewScopeDecorator[int](a)}, RequireAll, RequireOne)})
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{sequence, custom}))
+
+	buffer := NewRingBuffer[int](100)
+	tree.AddListener(buffer)
+	tree.Tick(0)
+	tree.Tick(0)
+
+	traced := make(map[Node[int]]bool)
+	for _, event := range buffer.Events() {
+		traced[event.Node] = true
+	}
+	if !traced[c] || !traced[b] || !traced[inner] {
+		t.Error("Expected nodes in nested trees and parallel nodes to be traced")
+	}
+	if custom.nodes[0] != a {
+		t.Errorf("Expected the children of custom nodes not to be replaced, but got %T", custom.nodes[0])
+	}
+
+	var visited []Node[int]
+	Walk[int](tree, func(node Node[int], depth int) bool {
+		visited = append(visited, node)
+		return true
+	})
+	for _, node := range visited {
+		if _, ok := node.(*tracedNode[int]); ok {
+			t.Errorf("Expected Walk to unwrap traced nodes, but visited %T", node)
+		}
+	}
+	if children := ChildrenOf[int](sequence); children[0] != inner {
+		t.Errorf("Expected ChildrenOf to unwrap traced nodes, but got %T", children[0])
+	}
+	if _, ok := sequence.Nodes[0].(*tracedNode[int]); !ok {
+		t.Errorf("Expected the Nodes field to hold the tracing proxy, but got %T", sequence.Nodes[0])
+	}
+
+	registry := newTestRegistry()
+	if _, err := registry.Describe(tree); err == nil || !strings.Contains(err.Error(), "Task") {
+		t.Errorf("Expected Describe to see the original tasks, but got %v", err)
+	}
+}
+
+func TestBehaviorTree_TraceRunningBranch(t *testing.T) {
+	task := statusTask(Running, Success)
+	random := NewRandom([]Node[int]{task})
+	tree := NewBehaviorTree[int](random)
+
+	if status := tree.Tick(0); status != Running {
+		t.Fatalf("Expected Running, but got %v", status)
+	}
+	buffer := NewRingBuffer[int](100)
+	tree.AddListener(buffer)
+	if status := tree.Tick(0); status != Success {
+		t.Fatalf("Expected Success, but got %v", status)
+	}
+	if _, ok := random.Node.(*tracedNode[int]); ok {
+		t.Error("Expected the active node to be cleared after success")
+	}
+	if names := eventNames(buffer.Events()); !reflect.DeepEqual(names[len(names)-4:], []string{"Task:Success", "Task:Finish", "Random:Success", "Random:Finish"}) {
+		t.Errorf("Unexpected events %v", names)
+	}
+}
+
+func TestTracedNode_Control(t *testing.T) {
+	blackboard := NewBlackboard()
+	tree := NewBehaviorTree[int](statusTask(Success))
+	tree.SetBlackboard(blackboard)
+	task := statusTask(Success)
+	traced := &tracedNode[int]{node: task, control: tree, tracer: &tracer[int]{tree: tree}}
+	task.SetControl(traced)
+
+	if task.Blackboard() != blackboard {
+		t.Error("Expected the traced node to see the blackboard of its control node")
+	}
+
+	traced.SetControl(nil)
+	traced.Running()
+	traced.Success()
+	traced.Fail()
+	if unwrap[int](traced) != task || unwrap[int](task) != task {
+		t.Error("Expected unwrap to return the traced node")
+	}
+
+	empty := NewDecorator[int](nil)
+	traced.tracer.instrument(empty)
+	if empty.Node != nil {
+		t.Error("Expected missing children to be skipped")
+	}
+}
+
+func TestLogListener(t *testing.T) {
+	var b bytes.Buffer
+	listener := NewLogListener[int](log.New(&b, "", 0))
+	task := Named(statusTask(Success), "bark")
+
+	listener.OnEvent(Event[int]{Node: task, Kind: EventSuccess, Tick: 3})
+	task.SetID("root/1")
+	listener.OnEvent(Event[int]{Node: task, Kind: EventRun, Tick: 4})
+
+	expected := "tick 3: bark: Success\ntick 4: bark (root/1): Run\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, b.String())
+	}
+	if NewLogListener[int](nil) == nil {
+		t.Error("Expected a listener using the standard logger")
+	}
+}
+
+func TestSlogListener(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+	task := Named(statusTask(Success), "bark")
+	task.SetID("root/0")
+
+	NewSlogListener[int](logger, slog.LevelInfo).OnEvent(Event[int]{Node: task, Kind: EventFailure, Tick: 7})
+
+	expected := `level=INFO msg="behaviortree event" tick=7 node=bark id=root/0 event=Failure` + "\n"
+	if b.String() != expected {
+		t.Errorf("Expected %q, but got %q", expected, b.String())
+	}
+	if NewSlogListener[int](nil, slog.LevelDebug) == nil {
+		t.Error("Expected a listener using the default logger")
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	buffer := NewRingBuffer[int](3)
+	for tick := uint64(1); tick <= 5; tick++ {
+		buffer.OnEvent(Event[int]{Tick: tick})
+	}
+
+	var ticks []uint64
+	for _, event := range buffer.Events() {
+		ticks = append(ticks, event.Tick)
+	}
+	if !reflect.DeepEqual(ticks, []uint64{3, 4, 5}) {
+		t.Errorf("Expected the three most recent events, but got %v", ticks)
+	}
+
+	small := NewRingBuffer[int](0)
+	small.OnEvent(Event[int]{Tick: 1})
+	small.OnEvent(Event[int]{Tick: 2})
+	if events := small.Events(); len(events) != 1 || events[0].Tick != 2 {
+		t.Errorf("Expected a buffer of at least one event, but got %v", events)
+	}
+}
@@ -0,0 +1,108 @@
+package behaviortree
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Kind classifies the nodes of a behavior tree for tools such as renderers and validators.
+type Kind int
+
+const (
+	// KindAction is a leaf node performing work, such as a Task.
+	KindAction Kind = iota
+	// KindCondition is a leaf node checking a condition without side effects.
+	KindCondition
+	// KindComposite is a node with any number of children, such as a Sequence.
+	KindComposite
+	// KindDecorator is a node wrapping a single child.
+	KindDecorator
+	// KindTree is a behavior tree nested as a node of another tree.
+	KindTree
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindCondition:
+		return "Condition"
+	case KindComposite:
+		return "Composite"
+	case KindDecorator:
+		return "Decorator"
+	case KindTree:
+		return "Tree"
+	default:
+		return "Action"
+	}
+}
+
+// ParentNode is implemented by nodes that have child nodes. All built-in composites, decorators and
+// behavior trees implement it, as do custom nodes embedding BranchNode or Decorator.
+type ParentNode[T any] interface {
+	// Children returns the child nodes in execution order.
+	Children() []Node[T]
+}
+
+// KindOf returns the kind of the node. Nodes report their kind through a Kind method; other nodes are
+// treated as composites if they have children and as actions otherwise.
+func KindOf[T any](node Node[T]) Kind {
+	if kinded, ok := node.(interface{ Kind() Kind }); ok {
+		return kinded.Kind()
+	}
+	if _, ok := node.(ParentNode[T]); ok {
+		return KindComposite
+	}
+	return KindAction
+}
+
+// ChildrenOf returns the child nodes of the node, or nil if it has none. Children wrapped for tracing
+// are returned unwrapped.
+func ChildrenOf[T any](node Node[T]) []Node[T] {
+	parent, ok := node.(ParentNode[T])
+	if !ok {
+		return nil
+	}
+	children := parent.Children()
+	for i, child := range children {
+		if _, traced := child.(*tracedNode[T]); traced {
+			unwrapped := make([]Node[T], len(children))
+			copy(unwrapped, children[:i])
+			for j := i; j < len(children); j++ {
+				unwrapped[j] = unwrap(children[j])
+			}
+			return unwrapped
+		}
+	}
+	return children
+}
+
+// Walk visits the tree rooted at root in depth-first order, calling visit with every node and its depth,
+// starting at 0 for the root. If visit returns false, the descendants of the node are skipped.
+func Walk[T any](root Node[T], visit func(node Node[T], depth int) bool) {
+	walk(root, 0, visit)
+}
+
+// walk visits node at the given depth and its descendants.
+func walk[T any](node Node[T], depth int, visit func(node Node[T], depth int) bool) {
+	node = unwrap(node)
+	if node == nil || !visit(node, depth) {
+		return
+	}
+	for _, child := range ChildrenOf(node) {
+		walk(child, depth+1, visit)
+	}
+}
+
+// TypeName returns the name of the node's type without package and type parameters, such as "Sequence".
+func TypeName[T any](node Node[T]) string {
+	t := reflect.TypeOf(node)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
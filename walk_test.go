@@ -0,0 +1,110 @@
+package behaviortree
+
+import (
+	"reflect"
+	"testing"
+)
+
+// parentNode is a custom node with children that does not report its kind.
+type parentNode struct {
+	MockNode[int]
+	nodes []Node[int]
+}
+
+func (p *parentNode) Children() []Node[int] {
+	return p.nodes
+}
+
+func TestKind_String(t *testing.T) {
+	cases := map[Kind]string{
+		KindAction:    "Action",
+		KindCondition: "Condition",
+		KindComposite: "Composite",
+		KindDecorator: "Decorator",
+		KindTree:      "Tree",
+		Kind(42):      "Action",
+	}
+	for kind, expected := range cases {
+		if kind.String() != expected {
+			t.Errorf("Expected %q, but got %q", expected, kind.String())
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	task := statusTask(Success)
+	cases := []struct {
+		node Node[int]
+		kind Kind
+	}{
+		{task, KindAction},
+		{NewSequence([]Node[int]{task}), KindComposite},
+		{NewPriority([]Node[int]{task}), KindComposite},
+		{NewRandom([]Node[int]{task}), KindComposite},
+		{NewParallel([]Node[int]{task}, RequireAll, RequireOne), KindComposite},
+		{NewInvertDecorator[int](task), KindDecorator},
+		{NewBehaviorTree[int](task), KindTree},
+		{&parentNode{}, KindComposite},
+		{NewMockNode[int](t), KindAction},
+	}
+	for _, c := range cases {
+		if kind := KindOf(c.node); kind != c.kind {
+			t.Errorf("%s: expected %v, but got %v", TypeName(c.node), c.kind, kind)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	a, b, c := statusTask(Success), statusTask(Success), statusTask(Success)
+	invert := NewInvertDecorator[int](b)
+	sequence := NewSequence([]Node[int]{a, invert})
+	tree := NewBehaviorTree[int](NewPriority([]Node[int]{sequence, c}))
+
+	var names []string
+	var depths []int
+	Walk[int](tree, func(node Node[int], depth int) bool {
+		names = append(names, TypeName(node))
+		depths = append(depths, depth)
+		return true
+	})
+
+	expectedNames := []string{"BehaviorTree", "Priority", "Sequence", "Task", "InvertDecorator", "Task", "Task"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected %v, but got %v", expectedNames, names)
+	}
+	expectedDepths := []int{0, 1, 2, 3, 3, 4, 2}
+	if !reflect.DeepEqual(depths, expectedDepths) {
+		t.Errorf("Expected depths %v, but got %v", expectedDepths, depths)
+	}
+}
+
+func TestWalk_SkipsDescendants(t *testing.T) {
+	sequence := NewSequence([]Node[int]{statusTask(Success), statusTask(Success)})
+	root := NewPriority([]Node[int]{sequence, statusTask(Success)})
+
+	count := 0
+	Walk[int](root, func(node Node[int], depth int) bool {
+		count++
+		return node != sequence
+	})
+	if count != 3 {
+		t.Errorf("Expected 3 visited nodes, but got %d", count)
+	}
+}
+
+func TestWalk_Nil(t *testing.T) {
+	Walk[int](nil, func(node Node[int], depth int) bool {
+		t.Error("Expected no node to be visited")
+		return true
+	})
+}
+
+func TestChildrenOf(t *testing.T) {
+	task := statusTask(Success)
+	if children := ChildrenOf[int](NewInvertDecorator[int](task)); len(children) != 1 || children[0] != task {
+		t.Errorf("Expected the decorated node, but got %v", children)
+	}
+	if children := ChildrenOf[int](task); children != nil {
+		t.Errorf("Expected no children, but got %v", children)
+	}
+}
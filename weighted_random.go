@@ -0,0 +1,169 @@
+package behaviortree
+
+import "math/rand"
+
+// WeightFunc returns the weight of a child node of a WeightedRandom node for the object.
+type WeightFunc[T any] func(object T) float64
+
+// WeightedRandom represents a composite node that picks its child nodes at random in proportion to their
+// weights. When the picked child fails, the node falls back to another child, picked among the remaining
+// ones in the same way, and it fails once every child with a positive weight has failed. It succeeds as
+// soon as a child succeeds, and resumes at a running child on the next tick. The order is drawn when
+// the node starts, from the static weights or, where set, the weight functions evaluated for the object.
+type WeightedRandom[T any] struct {
+	ControlNode Node[T]         // The control node managing this WeightedRandom node.
+	Nodes       []Node[T]       // The list of child nodes to pick from.
+	Weights     []float64       // The static weights of the child nodes; children without a weight have weight 1.
+	WeightFuncs []WeightFunc[T] // The optional weight functions of the child nodes, overriding the static weights.
+	Rand        *rand.Rand      // The source of the random choices, or nil to use the global source of math/rand.
+	Order       []int           // The indexes of the child nodes in the order drawn when the node started.
+	Candidate   int             // The position in Order of the currently executing child node.
+	NodeRunning bool            // Indicates whether the current child node is running.
+	Object      T               // The object shared across nodes during execution.
+	Identity                    // The optional name and the path ID of the node.
+	Origin                      // The definition a Registry built the node from, if any.
+}
+
+// NewWeightedRandom creates a new WeightedRandom node with the specified child nodes and their static
+// weights, such as 70, 25 and 5 for children picked 70%, 25% and 5% of the time.
+func NewWeightedRandom[T any](nodes []Node[T], weights []float64) *WeightedRandom[T] {
+	return &WeightedRandom[T]{
+		Nodes:   nodes,
+		Weights: weights,
+	}
+}
+
+// SetControl sets the control node for the WeightedRandom node.
+func (w *WeightedRandom[T]) SetControl(control Node[T]) {
+	w.ControlNode = control
+}
+
+// Start initializes the WeightedRandom node with the provided object and draws the order in which its
+// child nodes are tried. A running WeightedRandom node is left as is, so that it resumes at the running child.
+func (w *WeightedRandom[T]) Start(object T) {
+	if w.NodeRunning {
+		return
+	}
+	w.Object = object
+	w.Candidate = 0
+	w.Order = w.Order[:0]
+
+	weights := make([]float64, len(w.Nodes))
+	total := 0.0
+	positive := 0
+	for i := range w.Nodes {
+		weights[i] = w.weight(i, object)
+		if weights[i] > 0 {
+			total += weights[i]
+			positive++
+		}
+	}
+	// Draw as many times as there are positive weights rather than until the total reaches zero, which
+	// rounding errors may prevent; a pick beyond the rounded total falls back to the last positive weight.
+	for ; positive > 0; positive-- {
+		pick := randFloat64(w.Rand) * total
+		chosen := -1
+		for i, weight := range weights {
+			if weight <= 0 {
+				continue
+			}
+			chosen = i
+			if pick < weight {
+				break
+			}
+			pick -= weight
+		}
+		w.Order = append(w.Order, chosen)
+		total -= weights[chosen]
+		weights[chosen] = 0
+	}
+}
+
+// weight returns the weight of the child node at index i for the object.
+func (w *WeightedRandom[T]) weight(i int, object T) float64 {
+	if i < len(w.WeightFuncs) && w.WeightFuncs[i] != nil {
+		return w.WeightFuncs[i](object)
+	}
+	if i < len(w.Weights) {
+		return w.Weights[i]
+	}
+	return 1
+}
+
+// SetRand sets the source of the random choices of the WeightedRandom node.
+func (w *WeightedRandom[T]) SetRand(random *rand.Rand) {
+	w.Rand = random
+}
+
+// Run executes the current child node. If no child node has a positive weight, the node fails.
+func (w *WeightedRandom[T]) Run(object T) {
+	if w.Candidate >= len(w.Order) {
+		if w.ControlNode != nil {
+			w.ControlNode.Fail()
+		}
+		return
+	}
+	currentNode := w.Nodes[w.Order[w.Candidate]]
+	currentNode.SetControl(w)
+	if !w.NodeRunning {
+		currentNode.Start(object)
+	}
+	currentNode.Run(object)
+}
+
+// Success is called when a child node succeeds. It signals success to the control node.
+func (w *WeightedRandom[T]) Success() {
+	w.NodeRunning = false
+	if w.ControlNode != nil {
+		w.ControlNode.Success()
+	}
+}
+
+// Fail is called when a child node fails. It falls back to the next child node in the drawn order, or
+// signals failure to the control node if every candidate has failed.
+func (w *WeightedRandom[T]) Fail() {
+	w.NodeRunning = false
+	w.Candidate++
+	w.Run(w.Object)
+}
+
+// Finish is a placeholder method for when the WeightedRandom node finishes execution.
+func (w *WeightedRandom[T]) Finish(object T) {
+}
+
+// Running signals that the WeightedRandom node is still in progress to the control node.
+func (w *WeightedRandom[T]) Running() {
+	w.NodeRunning = true
+	if w.ControlNode != nil {
+		w.ControlNode.Running()
+	}
+}
+
+// Blackboard returns the blackboard provided by the control node, or nil if there is none.
+func (w *WeightedRandom[T]) Blackboard() *Blackboard {
+	return blackboardOf(w.ControlNode)
+}
+
+// Children returns the child nodes of the WeightedRandom node.
+func (w *WeightedRandom[T]) Children() []Node[T] {
+	return w.Nodes
+}
+
+// Kind reports that the WeightedRandom node is a composite node.
+func (w *WeightedRandom[T]) Kind() Kind {
+	return KindComposite
+}
+
+// replaceChild replaces the child node at index i.
+func (w *WeightedRandom[T]) replaceChild(i int, node Node[T]) {
+	w.Nodes[i] = node
+}
+
+// Halt interrupts the running child node and resets the WeightedRandom node.
+func (w *WeightedRandom[T]) Halt(object T) {
+	if w.NodeRunning && w.Candidate < len(w.Order) {
+		Halt(w.Nodes[w.Order[w.Candidate]], object)
+	}
+	w.NodeRunning = false
+	w.Candidate = 0
+}
@@ -0,0 +1,155 @@
+package behaviortree
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWeightedRandom_Distribution(t *testing.T) {
+	counts := make([]int, 4)
+	var nodes []Node[int]
+	for i := range counts {
+		i := i
+		nodes = append(nodes, NewTask(func(task *Task[int], obj int) {
+			counts[i]++
+			task.Success()
+		}))
+	}
+	tree := NewBehaviorTree[int](NewWeightedRandom(nodes, []float64{70, 25, 5, 0}))
+
+	for i := 0; i < 10000; i++ {
+		if status := tree.Tick(0); status != Success {
+			t.Fatalf("Expected Success, but got %v", status)
+		}
+	}
+	if counts[0] < 6500 || counts[0] > 7500 || counts[1] < 2000 || counts[1] > 3000 || counts[2] < 250 || counts[2] > 750 {
+		t.Errorf("Expected the children to be picked about 70%%, 25%% and 5%% of the time, but got %v", counts)
+	}
+	if counts[3] != 0 {
+		t.Errorf("Expected a child with weight 0 never to be picked, but got %d", counts[3])
+	}
+}
+
+func TestWeightedRandom_FallsBack(t *testing.T) {
+	first, second, third := newHaltableTask(Failure), newHaltableTask(Failure), newHaltableTask(Failure)
+	random := NewWeightedRandom([]Node[int]{first, second, third}, []float64{1, 2})
+	tree := NewBehaviorTree[int](random)
+
+	if status := tree.Tick(0); status != Failure {
+		t.Errorf("Expected Failure once every child has failed, but got %v", status)
+	}
+	if first.runs != 1 || second.runs != 1 || third.runs != 1 {
+		t.Errorf("Expected every child to be tried once, got %d, %d and %d runs", first.runs, second.runs, third.runs)
+	}
+
+	succeed := newHaltableTask(Success)
+	tree = NewBehaviorTree[int](NewWeightedRandom([]Node[int]{statusTask(Failure), succeed}, nil))
+	if status := tree.Tick(0); status != Success || succeed.runs != 1 {
+		t.Errorf("Expected the node to fall back to the succeeding child, but got %v", status)
+	}
+}
+
+func TestWeightedRandom_FractionalWeights(t *testing.T) {
+	random := NewWeightedRandom([]Node[int]{statusTask(Failure), statusTask(Failure), statusTask(Failure)}, []float64{0.1, 0.2, 0.3})
+	random.SetRand(rand.New(rand.NewSource(0)))
+	tree := NewBehaviorTree[int](random)
+
+	for i := 0; i < 1000; i++ {
+		if status := tree.Tick(0); status != Failure {
+			t.Fatalf("Expected Failure once every child has failed, but got %v", status)
+		}
+		order := append([]int(nil), random.Order...)
+		sort.Ints(order)
+		if !reflect.DeepEqual(order, []int{0, 1, 2}) {
+			t.Fatalf("Expected every child to be drawn once despite rounding errors, but got %v", random.Order)
+		}
+	}
+}
+
+func TestWeightedRandom_WeightFuncs(t *testing.T) {
+	idle, bark := newHaltableTask(Success), newHaltableTask(Success)
+	random := NewWeightedRandom([]Node[int]{idle, bark}, []float64{1, 1})
+	random.WeightFuncs = []WeightFunc[int]{nil, func(noise int) float64 { return float64(noise) }}
+	tree := NewBehaviorTree[int](random)
+
+	for i := 0; i < 10; i++ {
+		tree.Tick(0)
+	}
+	if bark.runs != 0 || idle.runs != 10 {
+		t.Errorf("Expected the weight function to keep the dog quiet, got %d barks", bark.runs)
+	}
+	if !reflect.DeepEqual(random.Order, []int{0}) {
+		t.Errorf("Expected only the first child to be a candidate, but got %v", random.Order)
+	}
+}
+
+func TestWeightedRandom_NoCandidates(t *testing.T) {
+	if status := TickNode[int](NewWeightedRandom[int](nil, nil), 0); status != Failure {
+		t.Errorf("Expected an empty node to fail, but got %v", status)
+	}
+	if status := TickNode[int](NewWeightedRandom([]Node[int]{statusTask(Success)}, []float64{0}), 0); status != Failure {
+		t.Errorf("Expected a node without positive weights to fail, but got %v", status)
+	}
+
+	random := NewWeightedRandom([]Node[int]{statusTask(Failure)}, nil)
+	random.Start(0)
+	random.Run(0)
+	random.Success()
+	if random.Candidate != 1 || random.NodeRunning {
+		t.Error("Expected the node to run without a control node")
+	}
+}
+
+func TestWeightedRandom_ResumesAndHalts(t *testing.T) {
+	child := newHaltableTask(Running, Success)
+	random := NewWeightedRandom([]Node[int]{statusTask(Failure), child}, []float64{1, 1})
+	tree := NewBehaviorTree[int](random)
+	var events []Event[int]
+	tree.AddListener(ListenerFunc[int](func(event Event[int]) { events = append(events, event) }))
+
+	statuses := []Status{tree.Tick(0)}
+	candidate := random.Candidate
+	random.Start(0)
+	statuses = append(statuses, tree.Tick(0))
+	if !reflect.DeepEqual(statuses, []Status{Running, Success}) || child.started != 1 || random.Candidate != candidate {
+		t.Errorf("Expected the node to resume at the running child, got %v and %d starts", statuses, child.started)
+	}
+	if len(events) == 0 || random.Blackboard() != tree.Blackboard() {
+		t.Error("Expected the children to be traced and to share the blackboard of the tree")
+	}
+
+	running := newHaltableTask(Running)
+	random = NewWeightedRandom([]Node[int]{running}, nil)
+	tree = NewBehaviorTree[int](random)
+	tree.Tick(0)
+	tree.Halt(0)
+	random.Halt(0)
+	if running.halted != 1 || random.NodeRunning || random.Candidate != 0 {
+		t.Errorf("Expected the running child to be halted once, but got %d", running.halted)
+	}
+	if kind := random.Kind(); kind != KindComposite {
+		t.Errorf("Expected KindComposite, but got %v", kind)
+	}
+}
+
+func TestRegistry_WeightedRandom(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree, err := registry.LoadXML([]byte(`<root><BehaviorTree><WeightedRandom weights="70,25,5"><Succeed/><Run/><Fail/></WeightedRandom></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if random := tree.RootNode.(*WeightedRandom[int]); !reflect.DeepEqual(random.Weights, []float64{70, 25, 5}) {
+		t.Errorf("Unexpected weights %v", random.Weights)
+	}
+
+	definition, ok := describeBuiltin[int](NewWeightedRandom([]Node[int]{statusTask(Success)}, []float64{0.5, 2}))
+	if !ok || definition.Params["weights"] != "0.5,2" {
+		t.Errorf("Expected the weights to be described, but got %+v", definition)
+	}
+	if _, err := registry.Build(Definition{Type: "WeightedRandom", Params: Params{"weights": "many"}}); err == nil {
+		t.Error("Expected an error for invalid weights")
+	}
+}
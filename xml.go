@@ -0,0 +1,310 @@
+package behaviortree
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// xmlMainTree is the ID of the tree written by EncodeXML.
+const xmlMainTree = "MainTree"
+
+// xmlTypes maps BehaviorTree.CPP element names onto the registry types of this package.
+// Elements that are not listed are looked up in the registry by their own name.
+var xmlTypes = map[string]string{
+	"Fallback":             "Priority",
+	"Inverter":             "Invert",
+	"ForceSuccess":         "AlwaysSucceed",
+	"ForceFailure":         "AlwaysFail",
+	"RetryUntilSuccessful": "Retry",
+}
+
+// xmlPorts maps the ports of BehaviorTree.CPP elements onto the params of the registry types.
+var xmlPorts = map[string]map[string]string{
+	"Parallel":             {"success_count": "success", "failure_count": "failure"},
+	"Repeat":               {"num_cycles": "count"},
+	"RetryUntilSuccessful": {"num_attempts": "attempts"},
+	"SubTree":              {"ID": "tree"},
+	"Timeout":              {"msec": "timeout"},
+}
+
+// xmlUnbounded lists the ports of BehaviorTree.CPP elements on which -1 stands for all children or no
+// limit. The registry types express it with zero, as RequireAll, RepeatForever and RetryForever, so the
+// values are translated, and a BehaviorTree.CPP zero, which has no counterpart, is rejected.
+var xmlUnbounded = map[string]map[string]bool{
+	"Parallel":             {"success_count": true, "failure_count": true},
+	"Repeat":               {"num_cycles": true},
+	"RetryUntilSuccessful": {"num_attempts": true},
+}
+
+// xmlMilliseconds lists the ports of BehaviorTree.CPP elements holding a number of milliseconds. The
+// registry types take durations such as "250ms", so the values are translated.
+var xmlMilliseconds = map[string]map[string]bool{
+	"Timeout": {"msec": true},
+}
+
+// xmlElement is a generic XML element of a BehaviorTree.CPP document.
+type xmlElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Children []xmlElement `xml:",any"`
+}
+
+// attr returns the value of the attribute with the given name.
+func (e *xmlElement) attr(name string) (string, bool) {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// ParseXML parses a BehaviorTree.CPP (Groot2) XML document and returns the definition of its main tree,
+// selected by the main_tree_to_execute attribute or, if absent, the only tree of the document:
+//
+//	<root BTCPP_format="4" main_tree_to_execute="MainTree">
+//		<BehaviorTree ID="MainTree">
+//			<Fallback>
+//				<Inverter><CheckBattery/></Inverter>
+//				<SubTree ID="Recharge"/>
+//			</Fallback>
+//		</BehaviorTree>
+//		...
+//	</root>
+//
+// Sequence, Fallback, ReactiveSequence, ReactiveFallback, Parallel, Inverter, ForceSuccess, ForceFailure,
+// Repeat, RetryUntilSuccessful and Timeout are mapped onto the built-in node types; other elements, as well
+// as Action, Condition, Decorator and Control elements with an ID attribute, are looked up in the registry
+// by name. The name attribute names the node, and other attributes become params. SubTree elements
+// referencing a tree of the document are expanded in place; other SubTree elements become SubTree
+// definitions, built from a library registered with RegisterLibrary. The value -1, which BehaviorTree.CPP
+// uses for all children or no limit, is translated to zero, as RequireAll, RepeatForever and RetryForever,
+// and back by EncodeXML. Likewise the msec attribute of Timeout is translated to a duration.
+// Errors are reported as a *LoadError with an XPath.
+func ParseXML(data []byte) (Definition, error) {
+	var root xmlElement
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return Definition{}, &LoadError{Path: "/", Err: err}
+	}
+	if root.XMLName.Local != "root" {
+		return Definition{}, &LoadError{Path: "/" + root.XMLName.Local, Err: errors.New("expected a root element")}
+	}
+
+	parser := xmlParser{trees: make(map[string]*xmlElement)}
+	var ids []string
+	for i := range root.Children {
+		tree := &root.Children[i]
+		if tree.XMLName.Local != "BehaviorTree" {
+			continue
+		}
+		id, _ := tree.attr("ID")
+		if _, ok := parser.trees[id]; ok {
+			return Definition{}, &LoadError{Path: xmlTreePath(id) + "/@ID", Err: errors.New("duplicate tree ID")}
+		}
+		parser.trees[id] = tree
+		ids = append(ids, id)
+	}
+
+	main, ok := root.attr("main_tree_to_execute")
+	if !ok {
+		if len(ids) != 1 {
+			return Definition{}, &LoadError{Path: "/root/@main_tree_to_execute", Err: fmt.Errorf("required when the document has %d trees", len(ids))}
+		}
+		main = ids[0]
+	}
+	return parser.tree(main, "/root/@main_tree_to_execute", nil)
+}
+
+// LoadXML builds a behavior tree from a BehaviorTree.CPP XML document as described by ParseXML.
+func (r *Registry[T]) LoadXML(data []byte) (*BehaviorTree[T], error) {
+	definition, err := ParseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	root, err := r.Build(definition)
+	if err != nil {
+		return nil, err
+	}
+	return NewBehaviorTree(root), nil
+}
+
+// xmlParser converts the trees of a BehaviorTree.CPP document into definitions.
+type xmlParser struct {
+	trees map[string]*xmlElement // The BehaviorTree elements by ID.
+}
+
+// tree converts the tree with the given ID. Path locates the reference to the tree, and stack holds
+// the IDs of the trees being expanded, used to detect recursive subtrees.
+func (p *xmlParser) tree(id, path string, stack []string) (Definition, error) {
+	for _, expanding := range stack {
+		if expanding == id {
+			return Definition{}, &LoadError{Path: path, Err: fmt.Errorf("tree %q includes itself", id)}
+		}
+	}
+	tree, ok := p.trees[id]
+	if !ok {
+		return Definition{}, &LoadError{Path: path, Err: fmt.Errorf("unknown tree %q", id)}
+	}
+	treePath := xmlTreePath(id)
+	if len(tree.Children) != 1 {
+		return Definition{}, &LoadError{Path: treePath, Err: fmt.Errorf("expected 1 root node, got %d", len(tree.Children))}
+	}
+	return p.node(&tree.Children[0], treePath+"/"+tree.Children[0].XMLName.Local, append(stack, id))
+}
+
+// node converts the element located at path and its descendants.
+func (p *xmlParser) node(element *xmlElement, path string, stack []string) (Definition, error) {
+	tag := element.XMLName.Local
+	nodeType := tag
+	switch tag {
+	case "SubTree":
+		id, ok := element.attr("ID")
+		if !ok {
+			return Definition{}, &LoadError{Path: path, Err: errors.New("missing ID attribute")}
+		}
+		if _, ok := p.trees[id]; ok {
+			return p.tree(id, path+"/@ID", stack)
+		}
+	case "Action", "Condition", "Decorator", "Control":
+		id, ok := element.attr("ID")
+		if !ok {
+			return Definition{}, &LoadError{Path: path, Err: errors.New("missing ID attribute")}
+		}
+		nodeType = id
+	}
+	if mapped, ok := xmlTypes[tag]; ok {
+		nodeType = mapped
+	}
+
+	definition := Definition{Type: nodeType}
+	for _, attr := range element.Attrs {
+		name := attr.Name.Local
+		if name == "name" {
+			definition.Name = attr.Value
+			continue
+		}
+		if name == "ID" && nodeType != tag {
+			continue
+		}
+		value := attr.Value
+		if xmlUnbounded[tag][name] {
+			switch value {
+			case "-1":
+				value = "0"
+			case "0":
+				return Definition{}, &LoadError{Path: path + "/@" + name, Err: errors.New("0 is not supported, use -1 for no limit")}
+			}
+		}
+		if xmlMilliseconds[tag][name] {
+			msec, err := strconv.Atoi(value)
+			if err != nil {
+				return Definition{}, &LoadError{Path: path + "/@" + name, Err: fmt.Errorf("expected a number of milliseconds, got %q", value)}
+			}
+			value = (time.Duration(msec) * time.Millisecond).String()
+		}
+		if port, ok := xmlPorts[tag][name]; ok {
+			name = port
+		}
+		if definition.Params == nil {
+			definition.Params = make(Params)
+		}
+		definition.Params[name] = value
+	}
+
+	counts := make(map[string]int)
+	for i := range element.Children {
+		child := &element.Children[i]
+		counts[child.XMLName.Local]++
+		childPath := path + "/" + child.XMLName.Local + "[" + strconv.Itoa(counts[child.XMLName.Local]) + "]"
+		childDefinition, err := p.node(child, childPath, stack)
+		if err != nil {
+			return Definition{}, err
+		}
+		definition.Children = append(definition.Children, childDefinition)
+	}
+	return definition, nil
+}
+
+// xmlTreePath returns the XPath of the BehaviorTree element with the given ID.
+func xmlTreePath(id string) string {
+	return "/root/BehaviorTree[@ID='" + id + "']"
+}
+
+// WriteXML writes the tree in the BehaviorTree.CPP XML format. The tree is described as by Describe,
+// so every node must either be built by the registry or be a built-in node.
+func (r *Registry[T]) WriteXML(w io.Writer, tree *BehaviorTree[T]) error {
+	definition, err := r.Describe(tree)
+	if err != nil {
+		return err
+	}
+	return EncodeXML(w, definition)
+}
+
+// EncodeXML writes the definition as the main tree of a BehaviorTree.CPP XML document. Built-in node
+// types are written using their BehaviorTree.CPP element names and params are written as attributes.
+func EncodeXML(w io.Writer, definition Definition) error {
+	root := xmlElement{
+		XMLName: xml.Name{Local: "root"},
+		Attrs: []xml.Attr{
+			{Name: xml.Name{Local: "BTCPP_format"}, Value: "4"},
+			{Name: xml.Name{Local: "main_tree_to_execute"}, Value: xmlMainTree},
+		},
+		Children: []xmlElement{{
+			XMLName:  xml.Name{Local: "BehaviorTree"},
+			Attrs:    []xml.Attr{{Name: xml.Name{Local: "ID"}, Value: xmlMainTree}},
+			Children: []xmlElement{xmlNode(definition)},
+		}},
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(&root)
+}
+
+// xmlNode returns the element for the definition and its descendants.
+func xmlNode(definition Definition) xmlElement {
+	tag := definition.Type
+	for xmlTag, nodeType := range xmlTypes {
+		if nodeType == definition.Type {
+			tag = xmlTag
+		}
+	}
+
+	names := make([]string, 0, len(definition.Params))
+	for name := range definition.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	element := xmlElement{XMLName: xml.Name{Local: tag}}
+	if definition.Name != "" {
+		element.Attrs = append(element.Attrs, xml.Attr{Name: xml.Name{Local: "name"}, Value: definition.Name})
+	}
+	for _, name := range names {
+		attr := name
+		for port, param := range xmlPorts[tag] {
+			if param == name {
+				attr = port
+			}
+		}
+		value := fmt.Sprint(definition.Params[name])
+		if xmlUnbounded[tag][attr] && value == "0" {
+			value = "-1"
+		}
+		if xmlMilliseconds[tag][attr] {
+			if duration, err := definition.Params.Duration(name, 0); err == nil {
+				value = strconv.FormatInt(int64(duration/time.Millisecond), 10)
+			}
+		}
+		element.Attrs = append(element.Attrs, xml.Attr{Name: xml.Name{Local: attr}, Value: value})
+	}
+	for _, child := range definition.Children {
+		element.Children = append(element.Children, xmlNode(child))
+	}
+	return element
+}
@@ -0,0 +1,238 @@
+package behaviortree
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testXML = `<?xml version="1.0"?>
+<root BTCPP_format="4" main_tree_to_execute="MainTree">
+  <BehaviorTree ID="MainTree">
+    <Fallback name="root">
+      <Sequence>
+        <Inverter><Succeed/></Inverter>
+        <ForceSuccess><Fail/></ForceSuccess>
+      </Sequence>
+      <Parallel success_count="1" failure_count="-1">
+        <Action ID="Run"/>
+        <SubTree ID="Finish"/>
+      </Parallel>
+    </Fallback>
+  </BehaviorTree>
+  <BehaviorTree ID="Finish">
+    <ForceFailure><Condition ID="Fail" threshold="3"/></ForceFailure>
+  </BehaviorTree>
+  <TreeNodesModel>
+    <Action ID="Run"/>
+  </TreeNodesModel>
+</root>`
+
+func TestParseXML(t *testing.T) {
+	definition, err := ParseXML([]byte(testXML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := Definition{Type: "Priority", Name: "root", Children: []Definition{
+		{Type: "Sequence", Children: []Definition{
+			{Type: "Invert", Children: []Definition{{Type: "Succeed"}}},
+			{Type: "AlwaysSucceed", Children: []Definition{{Type: "Fail"}}},
+		}},
+		{Type: "Parallel", Params: Params{"success": "1", "failure": "0"}, Children: []Definition{
+			{Type: "Run"},
+			{Type: "AlwaysFail", Children: []Definition{{Type: "Fail", Params: Params{"threshold": "3"}}}},
+		}},
+	}}
+	if !reflect.DeepEqual(definition, expected) {
+		t.Errorf("Unexpected definition:\n%+v\nexpected:\n%+v", definition, expected)
+	}
+}
+
+func TestParseXML_SingleTree(t *testing.T) {
+	definition, err := ParseXML([]byte(`<root><BehaviorTree ID="Only"><Succeed/></BehaviorTree></root>`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if definition.Type != "Succeed" {
+		t.Errorf("Expected the only tree to be used, but got %+v", definition)
+	}
+}
+
+func TestParseXML_Errors(t *testing.T) {
+	cases := []struct {
+		document string
+		path     string
+	}{
+		{`<root>`, "/"},
+		{`<tree/>`, "/tree"},
+		{`<root><BehaviorTree ID="A"><X/></BehaviorTree><BehaviorTree ID="A"><X/></BehaviorTree></root>`, "/root/BehaviorTree[@ID='A']/@ID"},
+		{`<root><BehaviorTree ID="A"><X/></BehaviorTree><BehaviorTree ID="B"><X/></BehaviorTree></root>`, "/root/@main_tree_to_execute"},
+		{`<root main_tree_to_execute="C"><BehaviorTree ID="A"><X/></BehaviorTree></root>`, "/root/@main_tree_to_execute"},
+		{`<root><BehaviorTree ID="A"><X/><Y/></BehaviorTree></root>`, "/root/BehaviorTree[@ID='A']"},
+		{`<root><BehaviorTree ID="A"><Sequence><X/><SubTree/></Sequence></BehaviorTree></root>`, "/root/BehaviorTree[@ID='A']/Sequence/SubTree[1]"},
+		{`<root><BehaviorTree ID="A"><Sequence><X/><X/><Action/></Sequence></BehaviorTree></root>`, "/root/BehaviorTree[@ID='A']/Sequence/Action[1]"},
+		{`<root main_tree_to_execute="A"><BehaviorTree ID="A"><Sequence><SubTree ID="B"/></Sequence></BehaviorTree><BehaviorTree ID="B"><SubTree ID="A"/></BehaviorTree></root>`, "/root/BehaviorTree[@ID='B']/SubTree/@ID"},
+		{`<root><BehaviorTree ID="A"><Parallel success_count="0"><X/></Parallel></BehaviorTree></root>`, "/root/BehaviorTree[@ID='A']/Parallel/@success_count"},
+	}
+	for _, c := range cases {
+		_, err := ParseXML([]byte(c.document))
+		var loadErr *LoadError
+		if !errors.As(err, &loadErr) {
+			t.Errorf("%s: expected a LoadError, but got %v", c.document, err)
+			continue
+		}
+		if loadErr.Path != c.path {
+			t.Errorf("%s: expected path %q, but got %q (%v)", c.document, c.path, loadErr.Path, err)
+		}
+	}
+}
+
+func TestRegistry_LoadXML(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree, err := registry.LoadXML([]byte(testXML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := tree.Tick(0); status != Running {
+		t.Errorf("Expected Running, but got %v", status)
+	}
+
+	if _, err := registry.LoadXML([]byte(`<root/>`)); err == nil {
+		t.Error("Expected an error for a document without trees")
+	}
+	if _, err := registry.LoadXML([]byte(`<root><BehaviorTree><Jump/></BehaviorTree></root>`)); err == nil {
+		t.Error("Expected an error for an unknown node type")
+	}
+}
+
+func TestRegistry_WriteXMLRoundTrip(t *testing.T) {
+	registry := newTestRegistry()
+	tree, err := registry.LoadXML([]byte(testXML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, tree); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `<root BTCPP_format="4" main_tree_to_execute="MainTree">
+  <BehaviorTree ID="MainTree">
+    <Fallback name="root">
+      <Sequence>
+        <Inverter>
+          <Succeed></Succeed>
+        </Inverter>
+        <ForceSuccess>
+          <Fail></Fail>
+        </ForceSuccess>
+      </Sequence>
+      <Parallel failure_count="-1" success_count="1">
+        <Run></Run>
+        <ForceFailure>
+          <Fail threshold="3"></Fail>
+        </ForceFailure>
+      </Parallel>
+    </Fallback>
+  </BehaviorTree>
+</root>`
+	if buf.String() != expected {
+		t.Errorf("Unexpected XML:\n%s", buf.String())
+	}
+
+	original, _ := ParseXML([]byte(testXML))
+	reparsed, err := ParseXML(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(original, reparsed) {
+		t.Errorf("Expected round trip to preserve the definition:\n%+v\n%+v", original, reparsed)
+	}
+}
+
+func TestRegistry_WriteXMLBuiltinNodes(t *testing.T) {
+	registry := newTestRegistry()
+	leaf, _ := registry.Build(Definition{Type: "Succeed"})
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{
+		NewPriority([]Node[int]{NewRandom([]Node[int]{leaf})}),
+		NewParallel([]Node[int]{leaf}, 2, RequireOne),
+		NewInvertDecorator[int](leaf),
+		NewAlwaysSucceedDecorator[int](leaf),
+		NewAlwaysFailDecorator[int](leaf),
+		NewUntilFailDecorator[int](leaf),
+		NewScopeDecorator[int](leaf),
+		NewBehaviorTree[int](leaf),
+	}))
+
+	definition, err := registry.Describe(tree)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var types []string
+	for _, child := range definition.Children {
+		types = append(types, child.Type)
+	}
+	expected := []string{"Priority", "Parallel", "Invert", "AlwaysSucceed", "AlwaysFail", "UntilFail", "Scope", "Succeed"}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("Expected types %v, but got %v", expected, types)
+	}
+
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, tree); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<Parallel failure_count="1" success_count="2">`) {
+		t.Errorf("Expected Parallel thresholds to be exported, got:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "<Random>") {
+		t.Errorf("Expected Random to be exported, got:\n%s", buf.String())
+	}
+}
+
+func TestRegistry_WriteXMLRequireAll(t *testing.T) {
+	registry := newTestRegistry()
+	leaf, _ := registry.Build(Definition{Type: "Succeed"})
+	tree := NewBehaviorTree[int](NewParallel([]Node[int]{leaf, leaf}, RequireAll, RequireOne))
+
+	var buf bytes.Buffer
+	if err := registry.WriteXML(&buf, tree); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<Parallel failure_count="1" success_count="-1">`) {
+		t.Errorf("Expected RequireAll to be exported as -1, got:\n%s", buf.String())
+	}
+
+	reloaded, err := registry.LoadXML(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if threshold := reloaded.RootNode.(*Parallel[int]).SuccessThreshold; threshold != RequireAll {
+		t.Errorf("Expected -1 to be imported as RequireAll, but got %d", threshold)
+	}
+}
+
+func TestRegistry_WriteXMLErrors(t *testing.T) {
+	registry := newTestRegistry()
+
+	tree := NewBehaviorTree[int](NewSequence([]Node[int]{Named(NewTask(func(task *Task[int], obj int) {}), "Idle")}))
+	err := registry.WriteXML(&bytes.Buffer{}, tree)
+	if err == nil || !strings.Contains(err.Error(), `node "Idle" at root/0 of type`) {
+		t.Errorf("Expected an error naming the task that was not built by the registry, but got %v", err)
+	}
+
+	if err := EncodeXML(failingWriter{}, Definition{Type: "Succeed"}); err == nil {
+		t.Error("Expected the writer error to be returned")
+	}
+}
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}